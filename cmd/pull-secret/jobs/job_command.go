@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gitlab.cee.redhat.com/service/hyperfleet/mvp/pkg/job"
+)
+
+// NewJobCommand builds the "run-job" command tree, registering every job this adapter knows how
+// to execute and wiring up metrics reporting shared across all of them.
+func NewJobCommand(ctx context.Context) *cobra.Command {
+	registry := job.NewJobRegistry()
+	registry.AddJob(&PullSecretJob{})
+
+	builder := (&job.CommandBuilder{}).SetRegistry(*registry).SetContext(ctx)
+
+	var metricsPushURL string
+	var metricsPushInterval time.Duration
+
+	cmd := builder.Build()
+	cmd.PersistentFlags().StringVar(&metricsPushURL, "metrics-push-url", "", "Prometheus Pushgateway URL to push job metrics to. Metrics are only reported to STDOUT when unset")
+	cmd.PersistentFlags().DurationVar(&metricsPushInterval, "metrics-push-interval", 30*time.Second, "how often to push metrics for long-running jobs, in addition to the final report")
+
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if metricsPushURL != "" {
+			builder.SetMetricsReporter(job.NewPeriodicReporter(job.NewPushgatewayReporter(metricsPushURL), metricsPushInterval))
+		}
+		return nil
+	}
+
+	return cmd
+}
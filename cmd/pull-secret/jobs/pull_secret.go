@@ -3,18 +3,21 @@ package jobs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-	"math/rand"
+	"io"
 	"os"
 	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
-	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/spf13/pflag"
 	"gitlab.cee.redhat.com/service/hyperfleet/mvp/pkg/job"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"gitlab.cee.redhat.com/service/hyperfleet/mvp/pkg/secretstore"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 const (
@@ -22,35 +25,125 @@ const (
 
 	// defaultPullSecretData is a fake pull secret used for testing when PULL_SECRET_DATA is not provided
 	defaultPullSecretData = `{"auths":{"cloud.openshift.com":{"auth":"ZmFrZXVzZXI6ZmFrZXBhc3N3b3Jk","email":"user@example.com"},"quay.io":{"auth":"ZmFrZXVzZXI6ZmFrZXBhc3N3b3Jk","email":"user@example.com"},"registry.connect.redhat.com":{"auth":"ZmFrZXVzZXI6ZmFrZXBhc3N3b3Jk","email":"user@example.com"},"registry.redhat.io":{"auth":"ZmFrZXVzZXI6ZmFrZXBhc3N3b3Jk","email":"user@example.com"}}}`
+
+	backendGCP   = "gcp"
+	backendVault = "vault"
+	backendAWS   = "aws"
+	backendK8s   = "k8s"
 )
 
 type PullSecretTask struct {
-	PullSecret   string
-	GCPProjectID string
-	ClusterID    string
-	SecretName   string
-	DryRun       bool
+	PullSecret  string
+	ClusterID   string
+	SecretName  string
+	DryRun      bool
+	BackendKind string
 }
 
 type PullSecretJob struct {
-	DryRun bool
+	DryRun        bool
+	Backend       string
+	AllowOverride bool
 }
 
+var _ job.RetryableJob = &PullSecretJob{}
+var _ job.StdinTasks = &PullSecretJob{}
+
 func (e PullSecretTask) TaskName() string {
 	return pullSecretTaskName
 }
 
+// PullSecretBatchRequest is the stdin payload accepted by PullSecretJob.TasksFromStdin: one entry
+// per cluster whose pull secret should be stored, so a single invocation can process a batch
+// without putting any of it in the environment table.
+type PullSecretBatchRequest struct {
+	Tasks []PullSecretTaskInput `json:"tasks"`
+}
+
+type PullSecretTaskInput struct {
+	ClusterID  string `json:"clusterId"`
+	SecretName string `json:"secretName,omitempty"`
+	PullSecret string `json:"pullSecret"`
+	// PullSecrets, when set, is merged (in order) into a single pull secret via PullSecretMerger
+	// instead of using PullSecret directly. This lets a caller combine e.g. a Red Hat entitlement
+	// secret with an internal mirror's credentials in one request.
+	PullSecrets []string `json:"pullSecrets,omitempty"`
+	DryRun      bool     `json:"dryRun,omitempty"`
+}
+
+// TasksFromStdin implements job.StdinTasks, decoding a PullSecretBatchRequest from decoder. This
+// is preferred over GetTasks whenever the caller pipes a request in, since it avoids putting the
+// pull secret (potentially many kilobytes of JSON with credentials) in the environment and lets
+// one invocation process a batch of N tasks.
+func (pullsecretJob *PullSecretJob) TasksFromStdin(decoder *json.Decoder) ([]job.Task, error) {
+	var req PullSecretBatchRequest
+	if err := decoder.Decode(&req); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("empty pull secret batch request")
+		}
+		return nil, fmt.Errorf("failed to decode pull secret batch request: %w", err)
+	}
+
+	backendKind := pullsecretJob.Backend
+	if backendKind == "" {
+		backendKind = backendGCP
+	}
+
+	merger := NewPullSecretMerger(pullsecretJob.AllowOverride)
+
+	tasks := make([]job.Task, 0, len(req.Tasks))
+	for _, input := range req.Tasks {
+		secretName := input.SecretName
+		if secretName == "" && input.ClusterID != "" {
+			secretName = fmt.Sprintf("hyperfleet-%s-pull-secret", input.ClusterID)
+		}
+
+		pullSecret, err := mergeIfMultiple(merger, input.PullSecret, input.PullSecrets)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", input.ClusterID, err)
+		}
+
+		tasks = append(tasks, PullSecretTask{
+			PullSecret:  pullSecret,
+			ClusterID:   input.ClusterID,
+			SecretName:  secretName,
+			DryRun:      input.DryRun || pullsecretJob.DryRun,
+			BackendKind: backendKind,
+		})
+	}
+
+	return tasks, nil
+}
+
+// mergeIfMultiple merges sources (when there is more than one) via merger; a single source, either
+// from sources or falling back to single, is returned unchanged so callers that only ever supply
+// one pull secret see no behavior change.
+func mergeIfMultiple(merger *PullSecretMerger, single string, sources []string) (string, error) {
+	if len(sources) == 0 {
+		return single, nil
+	}
+	if len(sources) == 1 {
+		return sources[0], nil
+	}
+	return merger.Merge(sources...)
+}
+
+// GetTasks builds a single task from environment variables. It remains as a fallback for k8s Job
+// compatibility; TasksFromStdin is preferred whenever the caller pipes a request on stdin.
 func (pullsecretJob *PullSecretJob) GetTasks() ([]job.Task, error) {
 
 	var tasks []job.Task
 
 	// Read configuration from environment variables
-	gcpProjectID := os.Getenv("GCP_PROJECT_ID")
 	clusterID := os.Getenv("CLUSTER_ID")
 	secretName := os.Getenv("SECRET_NAME")
-	pullSecretData := os.Getenv("PULL_SECRET_DATA")
 
-	// Use fake pull secret for testing if PULL_SECRET_DATA is not provided
+	pullSecretData, err := mergeIfMultiple(NewPullSecretMerger(pullsecretJob.AllowOverride), "", pullSecretSourcesFromEnv())
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge pull secret sources: %w", err)
+	}
+
+	// Use fake pull secret for testing if neither PULL_SECRET_DATA nor PULL_SECRET_DATA_* is provided
 	if pullSecretData == "" {
 		pullSecretData = defaultPullSecretData
 	}
@@ -60,100 +153,210 @@ func (pullsecretJob *PullSecretJob) GetTasks() ([]job.Task, error) {
 		secretName = fmt.Sprintf("hyperfleet-%s-pull-secret", clusterID)
 	}
 
+	backendKind := pullsecretJob.Backend
+	if backendKind == "" {
+		backendKind = backendGCP
+	}
+
 	tasks = append(tasks, PullSecretTask{
-		PullSecret:   pullSecretData,
-		GCPProjectID: gcpProjectID,
-		ClusterID:    clusterID,
-		SecretName:   secretName,
-		DryRun:       pullsecretJob.DryRun,
+		PullSecret:  pullSecretData,
+		ClusterID:   clusterID,
+		SecretName:  secretName,
+		DryRun:      pullsecretJob.DryRun,
+		BackendKind: backendKind,
 	})
 
 	return tasks, nil
 }
 
+// pullSecretSourcesFromEnv collects PULL_SECRET_DATA followed by the repeatable PULL_SECRET_DATA_1,
+// PULL_SECRET_DATA_2, ... variables (stopping at the first unset index), so GetTasks can merge
+// several partial pull secrets supplied through the environment.
+func pullSecretSourcesFromEnv() []string {
+	var sources []string
+	if data := os.Getenv("PULL_SECRET_DATA"); data != "" {
+		sources = append(sources, data)
+	}
+	for i := 1; ; i++ {
+		data := os.Getenv(fmt.Sprintf("PULL_SECRET_DATA_%d", i))
+		if data == "" {
+			break
+		}
+		sources = append(sources, data)
+	}
+	return sources
+}
+
+// newSecretBackend constructs the secretstore.Backend selected by kind, lazily so that a fresh
+// client is created on every call to Process (including retries). Each backend reads its own
+// connection details from the environment (GCP_PROJECT_ID, VAULT_ADDR/VAULT_MOUNT, the standard AWS
+// SDK credential chain, or SECRET_NAMESPACE plus the in-cluster Kubernetes config).
+func newSecretBackend(ctx context.Context, kind string) (secretstore.Backend, error) {
+	switch kind {
+	case "", backendGCP:
+		projectID := os.Getenv("GCP_PROJECT_ID")
+		if projectID == "" {
+			return nil, fmt.Errorf("missing required environment variable: GCP_PROJECT_ID")
+		}
+		client, err := secretmanager.NewClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create secretmanager client: %w", err)
+		}
+		return secretstore.NewGCPBackend(client, projectID), nil
+
+	case backendVault:
+		config := vaultapi.DefaultConfig()
+		if addr := os.Getenv("VAULT_ADDR"); addr != "" {
+			config.Address = addr
+		}
+		client, err := vaultapi.NewClient(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create vault client: %w", err)
+		}
+		mount := os.Getenv("VAULT_MOUNT")
+		if mount == "" {
+			mount = "secret"
+		}
+		return secretstore.NewVaultBackend(client, mount), nil
+
+	case backendAWS:
+		cfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		return secretstore.NewAWSBackend(secretsmanager.NewFromConfig(cfg)), nil
+
+	case backendK8s:
+		namespace := os.Getenv("SECRET_NAMESPACE")
+		if namespace == "" {
+			return nil, fmt.Errorf("missing required environment variable: SECRET_NAMESPACE")
+		}
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load in-cluster Kubernetes config: %w", err)
+		}
+		client, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
+		return secretstore.NewKubernetesBackend(client, namespace), nil
+
+	default:
+		return nil, fmt.Errorf("unknown secret backend %q, expected one of: gcp, vault, aws, k8s", kind)
+	}
+}
+
+// retryableFuncFor returns the IsRetryable predicate of the backend selected by kind, without
+// constructing a live client - only the error-classification logic is needed at retry-policy time.
+func retryableFuncFor(kind string) func(error) bool {
+	switch kind {
+	case backendVault:
+		return (&secretstore.VaultBackend{}).IsRetryable
+	case backendAWS:
+		return (&secretstore.AWSBackend{}).IsRetryable
+	case backendK8s:
+		return (&secretstore.KubernetesBackend{}).IsRetryable
+	default:
+		return (&secretstore.GCPBackend{}).IsRetryable
+	}
+}
+
 func (pullsecretJob *PullSecretJob) GetMetadata() job.Metadata {
 	return job.Metadata{
 		Use:         "pull-secret",
-		Description: "Pull Secret Job Execution - Stores pull secret in GCP Secret Manager",
+		Description: "Pull Secret Job Execution - Stores pull secret in a pluggable secret backend (GCP Secret Manager, Vault, AWS Secrets Manager, or a Kubernetes Secret)",
 	}
 }
 
 func (pullsecretJob *PullSecretJob) AddFlags(flags *pflag.FlagSet) {
+	defaultBackend := os.Getenv("SECRET_BACKEND")
+	if defaultBackend == "" {
+		defaultBackend = backendGCP
+	}
+
 	flags.BoolVar(&pullsecretJob.DryRun, "dry-run", false, "Dry run mode - validate authentication and configuration without creating/updating secrets")
+	flags.StringVar(&pullsecretJob.Backend, "backend", defaultBackend, "Secret backend to store the pull secret in: gcp, vault, aws, or k8s. Defaults to the SECRET_BACKEND environment variable")
+	flags.BoolVar(&pullsecretJob.AllowOverride, "allow-override", false, "When merging multiple pull secret sources, let a later source silently override an earlier one's conflicting auth instead of failing")
 }
 
 func (pullsecretJob *PullSecretJob) GetWorkerCount() int {
 	return 1
 }
 
+// DefaultRetryPolicy implements job.RetryableJob, retrying transient backend errors with
+// exponential backoff and jitter. Retryability is delegated to the selected backend, since each
+// one classifies its own transient errors.
+func (pullsecretJob *PullSecretJob) DefaultRetryPolicy() job.RetryPolicy {
+	return job.RetryPolicy{
+		MaxAttempts:    3,
+		BaseBackoff:    time.Second,
+		MaxBackoff:     30 * time.Second,
+		JitterFraction: 0.2,
+		RetryableFunc:  retryableFuncFor(pullsecretJob.Backend),
+	}
+}
+
+// tlog returns a TaskLogger scoped to this task, with cluster fields attached to every line.
+func (e PullSecretTask) tlog(ctx context.Context) job.TaskLogger {
+	return job.TaskLoggerFromContext(ctx).With("cluster_id", e.ClusterID)
+}
+
 func (e PullSecretTask) Process(ctx context.Context) error {
 
 	// Validate required environment variables
 	if err := e.validateConfig(); err != nil {
-		logStructured("error", e.ClusterID, e.GCPProjectID, "validate-config", 0, err.Error(), "")
+		e.tlog(ctx).Op("validate-config").Error(err, err.Error())
 		return err
 	}
 
 	// Validate pull secret JSON format
 	if err := validatePullSecret(e.PullSecret); err != nil {
-		logStructured("error", e.ClusterID, e.GCPProjectID, "validate-pull-secret", 0, fmt.Sprintf("Invalid pull secret format: %v", err), "")
+		e.tlog(ctx).Op("validate-pull-secret").Error(err, "invalid pull secret format")
 		return fmt.Errorf("invalid pull secret format: %w", err)
 	}
 
 	if e.DryRun {
-		logStructured("info", e.ClusterID, e.GCPProjectID, "start", 0, "Starting pull secret storage operation (DRY RUN MODE)", "")
+		e.tlog(ctx).Op("start").Info("Starting pull secret storage operation (DRY RUN MODE)")
 	} else {
-		logStructured("info", e.ClusterID, e.GCPProjectID, "start", 0, "Starting pull secret storage operation", "")
+		e.tlog(ctx).Op("start").Info("Starting pull secret storage operation")
 	}
 
-	// Initialize Secret Manager client
-	client, err := secretmanager.NewClient(ctx)
+	backend, err := newSecretBackend(ctx, e.BackendKind)
 	if err != nil {
-		logStructured("error", e.ClusterID, e.GCPProjectID, "init-client", 0, fmt.Sprintf("Failed to create secretmanager client: %v", err), "")
-		return fmt.Errorf("failed to create secretmanager client: %w", err)
+		e.tlog(ctx).Op("init-backend").Error(err, "Failed to initialize secret backend")
+		return err
 	}
-	defer func() {
-		if closeErr := client.Close(); closeErr != nil {
-			logStructured("error", e.ClusterID, e.GCPProjectID, "close-client", 0, fmt.Sprintf("Failed to close client: %v", closeErr), "")
-		}
-	}()
 
-	logStructured("info", e.ClusterID, e.GCPProjectID, "client-initialized", 0, "Successfully initialized Secret Manager client", "")
+	e.tlog(ctx).Op("backend-initialized").With("backend", e.BackendKind).Info("Successfully initialized secret backend")
 
 	// In dry-run mode, skip actual secret operations
 	if e.DryRun {
-		logStructured("info", e.ClusterID, e.GCPProjectID, "dry-run", 0, "DRY RUN: Skipping secret creation/update operations", "")
-		logStructured("info", e.ClusterID, e.GCPProjectID, "dry-run", 0, fmt.Sprintf("DRY RUN: Would create/update secret: %s", e.SecretName), "")
-		logStructured("info", e.ClusterID, e.GCPProjectID, "completed", 0, "DRY RUN completed successfully - authentication validated", "")
+		e.tlog(ctx).Op("dry-run").Info("DRY RUN: Skipping secret creation/update operations")
+		e.tlog(ctx).Op("dry-run").Info(fmt.Sprintf("DRY RUN: Would create/update secret: %s", e.SecretName))
+		e.tlog(ctx).Op("completed").Info("DRY RUN completed successfully - authentication validated")
 		return nil
 	}
 
-	// Create or update secret with retry logic
-	if err := retryWithBackoff(ctx, func() error {
-		return e.createOrUpdateSecret(ctx, client)
-	}, 3); err != nil {
-		logStructured("error", e.ClusterID, e.GCPProjectID, "create-update-secret", 0, fmt.Sprintf("Failed to create/update secret: %v", err), "")
+	// Create or update secret. Transient failures here are retried by the job framework, which
+	// retries the whole Process call according to PullSecretJob.DefaultRetryPolicy.
+	if err := e.createOrUpdateSecret(ctx, backend); err != nil {
+		e.tlog(ctx).Op("create-update-secret").Error(err, "Failed to create/update secret")
 		return err
 	}
 
 	// Verify secret is accessible
-	if err := retryWithBackoff(ctx, func() error {
-		return e.verifySecret(ctx, client)
-	}, 3); err != nil {
-		logStructured("error", e.ClusterID, e.GCPProjectID, "verify-secret", 0, fmt.Sprintf("Failed to verify secret: %v", err), "")
+	if err := e.verifySecret(ctx, backend); err != nil {
+		e.tlog(ctx).Op("verify-secret").Error(err, "Failed to verify secret")
 		return err
 	}
 
-	logStructured("info", e.ClusterID, e.GCPProjectID, "completed", 0, "Successfully created/updated pull secret", "")
+	e.tlog(ctx).Op("completed").Info("Successfully created/updated pull secret")
 
 	return nil
 }
 
 // validateConfig validates required environment variables
 func (e PullSecretTask) validateConfig() error {
-	if e.GCPProjectID == "" {
-		return fmt.Errorf("missing required environment variable: GCP_PROJECT_ID")
-	}
 	if e.ClusterID == "" {
 		return fmt.Errorf("missing required environment variable: CLUSTER_ID")
 	}
@@ -166,124 +369,54 @@ func (e PullSecretTask) validateConfig() error {
 	return nil
 }
 
-// createOrUpdateSecret creates or updates the secret in GCP Secret Manager
-func (e PullSecretTask) createOrUpdateSecret(ctx context.Context, client *secretmanager.Client) error {
+// createOrUpdateSecret creates or updates the secret in the configured backend
+func (e PullSecretTask) createOrUpdateSecret(ctx context.Context, backend secretstore.Backend) error {
 	startTime := time.Now()
 
-	// Check if secret exists
-	exists, err := e.secretExists(ctx, client)
+	exists, err := backend.Exists(ctx, e.SecretName)
 	if err != nil {
 		return err
 	}
 
 	if !exists {
-		// Create new secret
-		logStructured("info", e.ClusterID, e.GCPProjectID, "create-secret", 0, fmt.Sprintf("Creating new secret: %s", e.SecretName), "")
-		if createErr := e.createSecret(ctx, client); createErr != nil {
+		e.tlog(ctx).Op("create-secret").Info(fmt.Sprintf("Creating new secret: %s", e.SecretName))
+		labels := map[string]string{
+			"managed-by":         "hyperfleet",
+			"adapter":            "pullsecret",
+			"cluster-id":         e.ClusterID,
+			"resource-type":      "pull-secret",
+			"hyperfleet-version": "v1",
+		}
+		if createErr := backend.Create(ctx, e.SecretName, labels); createErr != nil {
 			return fmt.Errorf("failed to create secret: %w", createErr)
 		}
-		duration := time.Since(startTime).Milliseconds()
-		logStructured("info", e.ClusterID, e.GCPProjectID, "create-secret", duration, "Successfully created secret", "")
+		e.tlog(ctx).Op("create-secret").Duration(time.Since(startTime)).Info("Successfully created secret")
 	} else {
-		logStructured("info", e.ClusterID, e.GCPProjectID, "secret-exists", 0, fmt.Sprintf("Secret already exists: %s", e.SecretName), "")
+		e.tlog(ctx).Op("secret-exists").Info(fmt.Sprintf("Secret already exists: %s", e.SecretName))
 	}
 
 	// Add secret version with data
 	startTime = time.Now()
-	logStructured("info", e.ClusterID, e.GCPProjectID, "add-secret-version", 0, "Adding secret version with pull secret data", "")
-	version, err := e.addSecretVersion(ctx, client)
+	e.tlog(ctx).Op("add-secret-version").Info("Adding secret version with pull secret data")
+	version, err := backend.PutVersion(ctx, e.SecretName, []byte(e.PullSecret))
 	if err != nil {
 		return fmt.Errorf("failed to add secret version: %w", err)
 	}
-	duration := time.Since(startTime).Milliseconds()
-	logStructured("info", e.ClusterID, e.GCPProjectID, "add-secret-version", duration, "Successfully created secret version", version)
-
-	return nil
-}
-
-// secretExists checks if a secret exists in GCP Secret Manager
-func (e PullSecretTask) secretExists(ctx context.Context, client *secretmanager.Client) (bool, error) {
-	name := fmt.Sprintf("projects/%s/secrets/%s", e.GCPProjectID, e.SecretName)
-
-	req := &secretmanagerpb.GetSecretRequest{
-		Name: name,
-	}
-
-	_, err := client.GetSecret(ctx, req)
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return false, nil
-		}
-		return false, fmt.Errorf("failed to check secret existence: %w", err)
-	}
-
-	return true, nil
-}
-
-// createSecret creates a new secret in GCP Secret Manager
-func (e PullSecretTask) createSecret(ctx context.Context, client *secretmanager.Client) error {
-	req := &secretmanagerpb.CreateSecretRequest{
-		Parent:   fmt.Sprintf("projects/%s", e.GCPProjectID),
-		SecretId: e.SecretName,
-		Secret: &secretmanagerpb.Secret{
-			Replication: &secretmanagerpb.Replication{
-				Replication: &secretmanagerpb.Replication_Automatic_{
-					Automatic: &secretmanagerpb.Replication_Automatic{},
-				},
-			},
-			Labels: map[string]string{
-				"managed-by":         "hyperfleet",
-				"adapter":            "pullsecret",
-				"cluster-id":         e.ClusterID,
-				"resource-type":      "pull-secret",
-				"hyperfleet-version": "v1",
-			},
-		},
-	}
-
-	_, err := client.CreateSecret(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to create secret: %w", err)
-	}
+	e.tlog(ctx).Op("add-secret-version").Duration(time.Since(startTime)).With("version", version).Info("Successfully created secret version")
 
 	return nil
 }
 
-// addSecretVersion adds a new version with pull secret data
-func (e PullSecretTask) addSecretVersion(ctx context.Context, client *secretmanager.Client) (string, error) {
-	parent := fmt.Sprintf("projects/%s/secrets/%s", e.GCPProjectID, e.SecretName)
-
-	req := &secretmanagerpb.AddSecretVersionRequest{
-		Parent: parent,
-		Payload: &secretmanagerpb.SecretPayload{
-			Data: []byte(e.PullSecret),
-		},
-	}
-
-	version, err := client.AddSecretVersion(ctx, req)
-	if err != nil {
-		return "", fmt.Errorf("failed to add secret version: %w", err)
-	}
-
-	return version.Name, nil
-}
-
 // verifySecret verifies that the secret is accessible
-func (e PullSecretTask) verifySecret(ctx context.Context, client *secretmanager.Client) error {
+func (e PullSecretTask) verifySecret(ctx context.Context, backend secretstore.Backend) error {
 	startTime := time.Now()
-	name := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", e.GCPProjectID, e.SecretName)
 
-	req := &secretmanagerpb.AccessSecretVersionRequest{
-		Name: name,
-	}
-
-	result, err := client.AccessSecretVersion(ctx, req)
+	data, err := backend.Access(ctx, e.SecretName)
 	if err != nil {
 		return fmt.Errorf("failed to access secret version: %w", err)
 	}
 
-	duration := time.Since(startTime).Milliseconds()
-	logStructured("info", e.ClusterID, e.GCPProjectID, "verify-secret", duration, fmt.Sprintf("Verified secret (%d bytes)", len(result.Payload.Data)), "")
+	e.tlog(ctx).Op("verify-secret").Duration(time.Since(startTime)).Info(fmt.Sprintf("Verified secret (%d bytes)", len(data)))
 
 	return nil
 }
@@ -307,73 +440,3 @@ func validatePullSecret(pullSecretJSON string) error {
 
 	return nil
 }
-
-// retryWithBackoff retries a function with exponential backoff
-func retryWithBackoff(ctx context.Context, fn func() error, maxRetries int) error {
-	var err error
-	for i := 0; i < maxRetries; i++ {
-		err = fn()
-		if err == nil {
-			return nil
-		}
-
-		if !isRetryable(err) {
-			return err
-		}
-
-		if i < maxRetries-1 {
-			// Calculate backoff with jitter (Â±20%)
-			baseBackoff := time.Duration(1<<uint(i)) * time.Second
-			jitterRange := float64(baseBackoff) * 0.2
-			// Random value between -20% and +20% of base backoff
-			jitter := time.Duration((rand.Float64()*2 - 1) * jitterRange)
-			backoff := baseBackoff + jitter
-
-			log.Printf("Retry %d/%d after %s: %v", i+1, maxRetries, backoff, err)
-
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
-			}
-		}
-	}
-	return err
-}
-
-// isRetryable determines if an error is retryable
-func isRetryable(err error) bool {
-	code := status.Code(err)
-	return code == codes.Unavailable ||
-		code == codes.DeadlineExceeded ||
-		code == codes.Internal ||
-		code == codes.ResourceExhausted
-}
-
-// logStructured outputs structured JSON logs
-func logStructured(level, clusterID, gcpProject, operation string, durationMs int64, message, version string) {
-	logEntry := map[string]interface{}{
-		"timestamp":   time.Now().UTC().Format(time.RFC3339),
-		"level":       level,
-		"cluster_id":  clusterID,
-		"gcp_project": gcpProject,
-		"operation":   operation,
-		"message":     message,
-	}
-
-	if durationMs > 0 {
-		logEntry["duration_ms"] = durationMs
-	}
-
-	if version != "" {
-		logEntry["version"] = version
-	}
-
-	jsonLog, err := json.Marshal(logEntry)
-	if err != nil {
-		log.Printf("Failed to marshal log entry: %v", err)
-		return
-	}
-
-	fmt.Println(string(jsonLog))
-}
@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// dockerConfigJSON mirrors the subset of the docker config JSON schema
+// (https://github.com/docker/cli/blob/master/cli/config/configfile/file.go) that pull secrets
+// carry: per-registry auths, credential helpers, and extra HTTP headers.
+type dockerConfigJSON struct {
+	Auths       map[string]map[string]interface{} `json:"auths,omitempty"`
+	CredHelpers map[string]string                 `json:"credHelpers,omitempty"`
+	HTTPHeaders map[string]string                 `json:"HttpHeaders,omitempty"`
+}
+
+// PullSecretMerger combines several partial pull secrets (e.g. a Red Hat entitlement secret, an
+// internal mirror, and a quay.io robot account) into a single dockerconfigjson document, so callers
+// only have to store and distribute one secret.
+//
+// Registry hostnames are canonicalized before merging, so "docker.io" and its legacy alias
+// "index.docker.io" are treated as the same registry. Sources are applied in order: once a
+// hostname has been populated by an earlier source, a later source supplying a different value for
+// the same hostname is a conflict. A conflict is an error unless AllowOverride is set, in which case
+// the later source wins.
+type PullSecretMerger struct {
+	AllowOverride bool
+}
+
+// NewPullSecretMerger returns a PullSecretMerger with the given override policy.
+func NewPullSecretMerger(allowOverride bool) *PullSecretMerger {
+	return &PullSecretMerger{AllowOverride: allowOverride}
+}
+
+// Merge parses each of sources as a dockerconfigjson document and combines them into one, returning
+// the result as compact JSON. Merge returns an error if any source is invalid JSON or if two sources
+// disagree on a registry's auth, credential helper, or HTTP header and AllowOverride is false.
+func (m *PullSecretMerger) Merge(sources ...string) (string, error) {
+	if len(sources) == 0 {
+		return "", fmt.Errorf("no pull secret sources to merge")
+	}
+
+	merged := dockerConfigJSON{
+		Auths:       map[string]map[string]interface{}{},
+		CredHelpers: map[string]string{},
+		HTTPHeaders: map[string]string{},
+	}
+
+	for i, source := range sources {
+		var parsed dockerConfigJSON
+		if err := json.Unmarshal([]byte(source), &parsed); err != nil {
+			return "", fmt.Errorf("pull secret source %d: invalid JSON: %w", i, err)
+		}
+
+		for host, auth := range parsed.Auths {
+			host = canonicalizeRegistryHost(host)
+			if existing, ok := merged.Auths[host]; ok && !reflect.DeepEqual(existing, auth) {
+				if !m.AllowOverride {
+					return "", fmt.Errorf("conflicting auth for registry %q across pull secret sources (pass --allow-override to let later sources win)", host)
+				}
+			}
+			merged.Auths[host] = auth
+		}
+
+		for host, helper := range parsed.CredHelpers {
+			host = canonicalizeRegistryHost(host)
+			if existing, ok := merged.CredHelpers[host]; ok && existing != helper {
+				if !m.AllowOverride {
+					return "", fmt.Errorf("conflicting credHelper for registry %q across pull secret sources (pass --allow-override to let later sources win)", host)
+				}
+			}
+			merged.CredHelpers[host] = helper
+		}
+
+		for host, header := range parsed.HTTPHeaders {
+			host = canonicalizeRegistryHost(host)
+			if existing, ok := merged.HTTPHeaders[host]; ok && existing != header {
+				if !m.AllowOverride {
+					return "", fmt.Errorf("conflicting HttpHeaders entry for registry %q across pull secret sources (pass --allow-override to let later sources win)", host)
+				}
+			}
+			merged.HTTPHeaders[host] = header
+		}
+	}
+
+	if len(merged.Auths) == 0 {
+		merged.Auths = nil
+	}
+	if len(merged.CredHelpers) == 0 {
+		merged.CredHelpers = nil
+	}
+	if len(merged.HTTPHeaders) == 0 {
+		merged.HTTPHeaders = nil
+	}
+
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal merged pull secret: %w", err)
+	}
+	return string(out), nil
+}
+
+// canonicalizeRegistryHost normalizes a registry hostname so equivalent spellings merge into a
+// single entry: Docker Hub's legacy "index.docker.io" alias is rewritten to "docker.io", and any
+// trailing slash (as used by some legacy pull secret generators) is stripped.
+func canonicalizeRegistryHost(host string) string {
+	host = strings.TrimSuffix(host, "/")
+	if host == "index.docker.io" {
+		return "docker.io"
+	}
+	return host
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPullSecretMerger_Merge(t *testing.T) {
+	t.Run("merges distinct registries and preserves credHelpers", func(t *testing.T) {
+		entitlement := `{"auths":{"registry.redhat.io":{"auth":"cmVkaGF0OnNlY3JldA=="}}}`
+		quay := `{"auths":{"quay.io":{"auth":"cm9ib3Q6dG9rZW4="}},"credHelpers":{"us-docker.pkg.dev":"docker-credential-gcr"}}`
+
+		merger := NewPullSecretMerger(false)
+		merged, err := merger.Merge(entitlement, quay)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var parsed dockerConfigJSON
+		if err := json.Unmarshal([]byte(merged), &parsed); err != nil {
+			t.Fatalf("merged output is not valid JSON: %v", err)
+		}
+
+		if _, ok := parsed.Auths["registry.redhat.io"]; !ok {
+			t.Error("expected registry.redhat.io auth to be preserved")
+		}
+		if _, ok := parsed.Auths["quay.io"]; !ok {
+			t.Error("expected quay.io auth to be preserved")
+		}
+		if parsed.CredHelpers["us-docker.pkg.dev"] != "docker-credential-gcr" {
+			t.Errorf("expected credHelper to be preserved, got %+v", parsed.CredHelpers)
+		}
+	})
+
+	t.Run("canonicalizes docker hub aliases", func(t *testing.T) {
+		a := `{"auths":{"index.docker.io":{"auth":"YWxpY2U6cGFzcw=="}}}`
+		b := `{"auths":{"docker.io/":{"auth":"YWxpY2U6cGFzcw=="}}}`
+
+		merger := NewPullSecretMerger(false)
+		merged, err := merger.Merge(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error merging equivalent aliases: %v", err)
+		}
+
+		var parsed dockerConfigJSON
+		if err := json.Unmarshal([]byte(merged), &parsed); err != nil {
+			t.Fatalf("merged output is not valid JSON: %v", err)
+		}
+		if len(parsed.Auths) != 1 {
+			t.Errorf("expected docker.io aliases to collapse into a single entry, got %+v", parsed.Auths)
+		}
+		if _, ok := parsed.Auths["docker.io"]; !ok {
+			t.Errorf("expected canonical hostname 'docker.io', got %+v", parsed.Auths)
+		}
+	})
+
+	t.Run("rejects conflicting auths without allow-override", func(t *testing.T) {
+		a := `{"auths":{"quay.io":{"auth":"AAAA"}}}`
+		b := `{"auths":{"quay.io":{"auth":"BBBB"}}}`
+
+		merger := NewPullSecretMerger(false)
+		if _, err := merger.Merge(a, b); err == nil || !strings.Contains(err.Error(), "conflicting auth") {
+			t.Fatalf("expected a conflicting auth error, got: %v", err)
+		}
+	})
+
+	t.Run("allow-override lets the later source win", func(t *testing.T) {
+		a := `{"auths":{"quay.io":{"auth":"AAAA"}}}`
+		b := `{"auths":{"quay.io":{"auth":"BBBB"}}}`
+
+		merger := NewPullSecretMerger(true)
+		merged, err := merger.Merge(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var parsed dockerConfigJSON
+		if err := json.Unmarshal([]byte(merged), &parsed); err != nil {
+			t.Fatalf("merged output is not valid JSON: %v", err)
+		}
+		if parsed.Auths["quay.io"]["auth"] != "BBBB" {
+			t.Errorf("expected later source to win, got %+v", parsed.Auths["quay.io"])
+		}
+	})
+}
@@ -1,9 +1,13 @@
 package jobs
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -103,30 +107,18 @@ func TestPullSecretTask_validateConfig(t *testing.T) {
 	}{
 		{
 			name: "valid configuration",
-			task: PullSecretTask{
-				GCPProjectID: "test-project",
-				ClusterID:    "cls-123",
-				SecretName:   "test-secret",
-				PullSecret:   "test-data",
-			},
-			expectError: false,
-		},
-		{
-			name: "missing GCP project ID",
 			task: PullSecretTask{
 				ClusterID:  "cls-123",
 				SecretName: "test-secret",
 				PullSecret: "test-data",
 			},
-			expectError: true,
-			errorMsg:    "GCP_PROJECT_ID",
+			expectError: false,
 		},
 		{
 			name: "missing cluster ID",
 			task: PullSecretTask{
-				GCPProjectID: "test-project",
-				SecretName:   "test-secret",
-				PullSecret:   "test-data",
+				SecretName: "test-secret",
+				PullSecret: "test-data",
 			},
 			expectError: true,
 			errorMsg:    "CLUSTER_ID",
@@ -134,9 +126,8 @@ func TestPullSecretTask_validateConfig(t *testing.T) {
 		{
 			name: "missing secret name",
 			task: PullSecretTask{
-				GCPProjectID: "test-project",
-				ClusterID:    "cls-123",
-				PullSecret:   "test-data",
+				ClusterID:  "cls-123",
+				PullSecret: "test-data",
 			},
 			expectError: true,
 			errorMsg:    "SECRET_NAME",
@@ -144,9 +135,8 @@ func TestPullSecretTask_validateConfig(t *testing.T) {
 		{
 			name: "missing pull secret data",
 			task: PullSecretTask{
-				GCPProjectID: "test-project",
-				ClusterID:    "cls-123",
-				SecretName:   "test-secret",
+				ClusterID:  "cls-123",
+				SecretName: "test-secret",
 			},
 			expectError: true,
 			errorMsg:    "PULL_SECRET_DATA",
@@ -155,7 +145,7 @@ func TestPullSecretTask_validateConfig(t *testing.T) {
 			name:        "all fields empty",
 			task:        PullSecretTask{},
 			expectError: true,
-			errorMsg:    "GCP_PROJECT_ID",
+			errorMsg:    "CLUSTER_ID",
 		},
 	}
 
@@ -214,10 +204,11 @@ func TestPullSecretJob_GetWorkerCount(t *testing.T) {
 // TestPullSecretJob_GetTasks tests the GetTasks method with environment variables
 func TestPullSecretJob_GetTasks(t *testing.T) {
 	tests := []struct {
-		name        string
-		envVars     map[string]string
-		expectError bool
-		checkTask   func(*testing.T, PullSecretTask)
+		name          string
+		envVars       map[string]string
+		allowOverride bool
+		expectError   bool
+		checkTask     func(*testing.T, PullSecretTask)
 	}{
 		{
 			name: "all environment variables set",
@@ -229,15 +220,15 @@ func TestPullSecretJob_GetTasks(t *testing.T) {
 			},
 			expectError: false,
 			checkTask: func(t *testing.T, task PullSecretTask) {
-				if task.GCPProjectID != "test-project" {
-					t.Errorf("expected GCPProjectID 'test-project', got '%s'", task.GCPProjectID)
-				}
 				if task.ClusterID != "cls-123" {
 					t.Errorf("expected ClusterID 'cls-123', got '%s'", task.ClusterID)
 				}
 				if task.SecretName != "custom-secret" {
 					t.Errorf("expected SecretName 'custom-secret', got '%s'", task.SecretName)
 				}
+				if task.BackendKind != backendGCP {
+					t.Errorf("expected BackendKind '%s', got '%s'", backendGCP, task.BackendKind)
+				}
 			},
 		},
 		{
@@ -263,6 +254,64 @@ func TestPullSecretJob_GetTasks(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "merges PULL_SECRET_DATA with repeatable PULL_SECRET_DATA_* sources",
+			envVars: map[string]string{
+				"CLUSTER_ID":         "cls-multi",
+				"PULL_SECRET_DATA":   `{"auths":{"registry.redhat.io":{"auth":"cmVkaGF0"}}}`,
+				"PULL_SECRET_DATA_1": `{"auths":{"quay.io":{"auth":"cXVheQ=="}}}`,
+			},
+			expectError: false,
+			checkTask: func(t *testing.T, task PullSecretTask) {
+				var merged map[string]interface{}
+				if err := json.Unmarshal([]byte(task.PullSecret), &merged); err != nil {
+					t.Fatalf("expected merged PullSecret to be valid JSON, got error: %v", err)
+				}
+				auths, ok := merged["auths"].(map[string]interface{})
+				if !ok {
+					t.Fatalf("expected merged PullSecret to have an 'auths' object, got %v", merged)
+				}
+				if len(auths) != 2 {
+					t.Errorf("expected auths from both PULL_SECRET_DATA and PULL_SECRET_DATA_1, got %v", auths)
+				}
+				if _, ok := auths["registry.redhat.io"]; !ok {
+					t.Errorf("expected merged auths to contain registry.redhat.io, got %v", auths)
+				}
+				if _, ok := auths["quay.io"]; !ok {
+					t.Errorf("expected merged auths to contain quay.io, got %v", auths)
+				}
+			},
+		},
+		{
+			name: "conflicting PULL_SECRET_DATA_* sources return an error without AllowOverride",
+			envVars: map[string]string{
+				"CLUSTER_ID":         "cls-conflict",
+				"PULL_SECRET_DATA":   `{"auths":{"registry.redhat.io":{"auth":"cmVkaGF0"}}}`,
+				"PULL_SECRET_DATA_1": `{"auths":{"registry.redhat.io":{"auth":"b3ZlcnJpZGU="}}}`,
+			},
+			expectError: true,
+		},
+		{
+			name: "conflicting PULL_SECRET_DATA_* sources let a later one override with AllowOverride",
+			envVars: map[string]string{
+				"CLUSTER_ID":         "cls-override",
+				"PULL_SECRET_DATA":   `{"auths":{"registry.redhat.io":{"auth":"cmVkaGF0"}}}`,
+				"PULL_SECRET_DATA_1": `{"auths":{"registry.redhat.io":{"auth":"b3ZlcnJpZGU="}}}`,
+			},
+			allowOverride: true,
+			expectError:   false,
+			checkTask: func(t *testing.T, task PullSecretTask) {
+				var merged map[string]interface{}
+				if err := json.Unmarshal([]byte(task.PullSecret), &merged); err != nil {
+					t.Fatalf("expected merged PullSecret to be valid JSON, got error: %v", err)
+				}
+				auths := merged["auths"].(map[string]interface{})
+				entry := auths["registry.redhat.io"].(map[string]interface{})
+				if entry["auth"] != "b3ZlcnJpZGU=" {
+					t.Errorf("expected the later source's auth to win, got %v", entry)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -274,7 +323,7 @@ func TestPullSecretJob_GetTasks(t *testing.T) {
 			}
 			defer os.Clearenv()
 
-			job := &PullSecretJob{}
+			job := &PullSecretJob{AllowOverride: tt.allowOverride}
 			tasks, err := job.GetTasks()
 
 			if tt.expectError {
@@ -307,218 +356,74 @@ func TestPullSecretJob_GetTasks(t *testing.T) {
 	}
 }
 
-// TestIsRetryable tests the retry logic for different error codes
-func TestIsRetryable(t *testing.T) {
-	tests := []struct {
-		name     string
-		err      error
-		expected bool
-	}{
-		{
-			name:     "retryable - Unavailable",
-			err:      status.Error(codes.Unavailable, "service unavailable"),
-			expected: true,
-		},
-		{
-			name:     "retryable - DeadlineExceeded",
-			err:      status.Error(codes.DeadlineExceeded, "deadline exceeded"),
-			expected: true,
-		},
-		{
-			name:     "retryable - Internal",
-			err:      status.Error(codes.Internal, "internal error"),
-			expected: true,
-		},
-		{
-			name:     "retryable - ResourceExhausted",
-			err:      status.Error(codes.ResourceExhausted, "rate limit exceeded"),
-			expected: true,
-		},
-		{
-			name:     "not retryable - PermissionDenied",
-			err:      status.Error(codes.PermissionDenied, "permission denied"),
-			expected: false,
-		},
-		{
-			name:     "not retryable - NotFound",
-			err:      status.Error(codes.NotFound, "not found"),
-			expected: false,
-		},
-		{
-			name:     "not retryable - AlreadyExists",
-			err:      status.Error(codes.AlreadyExists, "already exists"),
-			expected: false,
-		},
-		{
-			name:     "not retryable - InvalidArgument",
-			err:      status.Error(codes.InvalidArgument, "invalid argument"),
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := isRetryable(tt.err)
-			if result != tt.expected {
-				t.Errorf("expected isRetryable(%v) = %v, got %v", tt.err, tt.expected, result)
-			}
-		})
-	}
-}
-
-// TestRetryWithBackoff tests the retry mechanism
-func TestRetryWithBackoff(t *testing.T) {
-	t.Run("success on first try", func(t *testing.T) {
-		attempts := 0
-		fn := func() error {
-			attempts++
-			return nil
-		}
-
-		ctx := context.Background()
-		err := retryWithBackoff(ctx, fn, 3)
+// TestPullSecretJob_TasksFromStdin tests decoding a batch request from stdin
+func TestPullSecretJob_TasksFromStdin(t *testing.T) {
+	t.Run("decodes a batch of tasks", func(t *testing.T) {
+		body := `{"tasks":[
+			{"clusterId":"cls-1","pullSecret":"{\"auths\":{}}"},
+			{"clusterId":"cls-2","secretName":"custom-secret","pullSecret":"{\"auths\":{}}","dryRun":true}
+		]}`
 
+		job := &PullSecretJob{Backend: backendVault}
+		tasks, err := job.TasksFromStdin(json.NewDecoder(bytes.NewBufferString(body)))
 		if err != nil {
-			t.Errorf("expected no error, got: %v", err)
+			t.Fatalf("unexpected error: %v", err)
 		}
-
-		if attempts != 1 {
-			t.Errorf("expected 1 attempt, got %d", attempts)
-		}
-	})
-
-	t.Run("success after retries", func(t *testing.T) {
-		attempts := 0
-		fn := func() error {
-			attempts++
-			if attempts < 3 {
-				return status.Error(codes.Unavailable, "unavailable")
-			}
-			return nil
-		}
-
-		ctx := context.Background()
-		err := retryWithBackoff(ctx, fn, 5)
-
-		if err != nil {
-			t.Errorf("expected no error, got: %v", err)
+		if len(tasks) != 2 {
+			t.Fatalf("expected 2 tasks, got %d", len(tasks))
 		}
 
-		if attempts != 3 {
-			t.Errorf("expected 3 attempts, got %d", attempts)
+		first := tasks[0].(PullSecretTask)
+		if first.ClusterID != "cls-1" || first.SecretName != "hyperfleet-cls-1-pull-secret" {
+			t.Errorf("unexpected first task: %+v", first)
 		}
-	})
-
-	t.Run("max retries exceeded", func(t *testing.T) {
-		attempts := 0
-		fn := func() error {
-			attempts++
-			return status.Error(codes.Unavailable, "unavailable")
+		if first.BackendKind != backendVault {
+			t.Errorf("expected BackendKind %q, got %q", backendVault, first.BackendKind)
 		}
 
-		ctx := context.Background()
-		err := retryWithBackoff(ctx, fn, 3)
-
-		if err == nil {
-			t.Error("expected error, got nil")
-		}
-
-		if attempts != 3 {
-			t.Errorf("expected 3 attempts, got %d", attempts)
+		second := tasks[1].(PullSecretTask)
+		if second.SecretName != "custom-secret" || !second.DryRun {
+			t.Errorf("unexpected second task: %+v", second)
 		}
 	})
 
-	t.Run("non-retryable error stops immediately", func(t *testing.T) {
-		attempts := 0
-		fn := func() error {
-			attempts++
-			return status.Error(codes.PermissionDenied, "permission denied")
-		}
-
-		ctx := context.Background()
-		err := retryWithBackoff(ctx, fn, 3)
-
-		if err == nil {
-			t.Error("expected error, got nil")
-		}
-
-		if attempts != 1 {
-			t.Errorf("expected 1 attempt (no retries), got %d", attempts)
+	t.Run("empty body returns an error", func(t *testing.T) {
+		job := &PullSecretJob{}
+		if _, err := job.TasksFromStdin(json.NewDecoder(bytes.NewBufferString(""))); err == nil {
+			t.Error("expected an error for an empty batch request, got nil")
 		}
 	})
+}
 
-	t.Run("context cancellation", func(t *testing.T) {
-		attempts := 0
-		fn := func() error {
-			attempts++
-			return status.Error(codes.Unavailable, "unavailable")
-		}
-
-		ctx, cancel := context.WithCancel(context.Background())
-		cancel() // Cancel immediately
-
-		err := retryWithBackoff(ctx, fn, 3)
+// TestPullSecretJob_DefaultRetryPolicy tests that the job declares a sane default retry policy
+func TestPullSecretJob_DefaultRetryPolicy(t *testing.T) {
+	j := &PullSecretJob{}
+	policy := j.DefaultRetryPolicy()
 
-		if err != context.Canceled {
-			t.Errorf("expected context.Canceled error, got: %v", err)
-		}
-	})
+	if policy.MaxAttempts < 1 {
+		t.Errorf("expected MaxAttempts >= 1, got %d", policy.MaxAttempts)
+	}
+	if policy.RetryableFunc == nil {
+		t.Error("expected RetryableFunc to be set")
+	}
+	if !policy.RetryableFunc(status.Error(codes.Unavailable, "unavailable")) {
+		t.Error("expected Unavailable to be retryable")
+	}
+	if policy.RetryableFunc(status.Error(codes.PermissionDenied, "permission denied")) {
+		t.Error("expected PermissionDenied to not be retryable")
+	}
 }
 
-// TestLogStructured verifies that logStructured doesn't panic
-func TestLogStructured(t *testing.T) {
-	tests := []struct {
-		name       string
-		level      string
-		clusterID  string
-		gcpProject string
-		operation  string
-		durationMs int64
-		message    string
-		version    string
-	}{
-		{
-			name:       "complete log entry",
-			level:      "info",
-			clusterID:  "cls-123",
-			gcpProject: "test-project",
-			operation:  "test-operation",
-			durationMs: 100,
-			message:    "test message",
-			version:    "v1",
-		},
-		{
-			name:       "log entry without duration",
-			level:      "error",
-			clusterID:  "cls-456",
-			gcpProject: "test-project",
-			operation:  "test-operation",
-			durationMs: 0,
-			message:    "error message",
-			version:    "",
-		},
-		{
-			name:       "log entry without version",
-			level:      "info",
-			clusterID:  "cls-789",
-			gcpProject: "test-project",
-			operation:  "test-operation",
-			durationMs: 200,
-			message:    "test message",
-			version:    "",
-		},
-	}
+// TestPullSecretTask_tlog verifies that the task logger helper doesn't panic
+func TestPullSecretTask_tlog(t *testing.T) {
+	task := PullSecretTask{ClusterID: "cls-123"}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Test should not panic
-			defer func() {
-				if r := recover(); r != nil {
-					t.Errorf("logStructured panicked: %v", r)
-				}
-			}()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("tlog panicked: %v", r)
+		}
+	}()
 
-			logStructured(tt.level, tt.clusterID, tt.gcpProject, tt.operation, tt.durationMs, tt.message, tt.version)
-		})
-	}
+	task.tlog(context.Background()).Op("test-operation").Duration(100 * time.Millisecond).Info("test message")
+	task.tlog(context.Background()).Op("test-operation").Error(fmt.Errorf("boom"), "error message")
 }
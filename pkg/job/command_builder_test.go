@@ -0,0 +1,98 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// stdinRetryableJob is a StdinTasks + RetryableJob fake used to exercise CommandBuilder.Build's
+// RunE closure end-to-end, including the order WithRetry and WithResultEncoding get applied in.
+type stdinRetryableJob struct {
+	attemptsByTask map[string]*int
+}
+
+func (j *stdinRetryableJob) GetMetadata() Metadata         { return Metadata{Use: "stdin-retryable-fake"} }
+func (j *stdinRetryableJob) AddFlags(flags *pflag.FlagSet) {}
+func (j *stdinRetryableJob) GetTasks() ([]Task, error)     { return nil, nil }
+func (j *stdinRetryableJob) GetWorkerCount() int           { return 1 }
+
+func (j *stdinRetryableJob) DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryableFunc: alwaysRetryable}
+}
+
+func (j *stdinRetryableJob) TasksFromStdin(decoder *json.Decoder) ([]Task, error) {
+	var names []string
+	if err := decoder.Decode(&names); err != nil {
+		return nil, err
+	}
+	tasks := make([]Task, len(names))
+	for i, name := range names {
+		name := name
+		attempts := 0
+		j.attemptsByTask[name] = &attempts
+		tasks[i] = &policyFakeTask{name: name, process: func(ctx context.Context) error {
+			attempts++
+			if name == "flaky" && attempts < 2 {
+				return errRetryable
+			}
+			return nil
+		}}
+	}
+	return tasks, nil
+}
+
+// TestCommandBuilder_StdinAndRetryTogether_WritesOneResultPerTask guards against a regression
+// where wrapping a stdin-decoded task in WithResultEncoding before WithRetry caused a TaskResult
+// to be written once per retry attempt instead of once per task.
+func TestCommandBuilder_StdinAndRetryTogether_WritesOneResultPerTask(t *testing.T) {
+	job := &stdinRetryableJob{attemptsByTask: map[string]*int{}}
+
+	registry := NewJobRegistry()
+	registry.AddJob(job)
+
+	builder := (&CommandBuilder{}).SetRegistry(*registry).SetContext(context.Background()).SetMetricsReporter(NewStdoutReporter())
+	cmd := builder.Build()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(bytes.NewBufferString(`["ok", "flaky"]`))
+	cmd.SetArgs([]string{job.GetMetadata().Use})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attempts := *job.attemptsByTask["flaky"]; attempts != 2 {
+		t.Fatalf("expected the flaky task to be retried once before succeeding, got %d attempts", attempts)
+	}
+
+	decoder := json.NewDecoder(&out)
+	var results []TaskResult
+	for {
+		var result TaskResult
+		err := decoder.Decode(&result)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to decode result stream: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected exactly 1 TaskResult per task (2 total) even though one task was retried, got %d: %+v", len(results), results)
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			t.Errorf("expected task %q to eventually succeed, got error %q", result.TaskName, result.Error)
+		}
+	}
+}
@@ -2,10 +2,14 @@ package job
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	logger "github.com/openshift-online/ocm-service-common/pkg/ocmlogger"
 	"github.com/segmentio/ksuid"
@@ -30,6 +34,51 @@ type Job interface {
 	GetWorkerCount() int
 }
 
+// JobPolicy configures optional per-task timeout and failure-tolerance behavior for a job.
+type JobPolicy struct {
+	// TaskTimeout, when greater than zero, bounds how long a single task.Process call may run
+	// before it is canceled and counted as a failed (and timed-out) task.
+	TaskTimeout time.Duration
+	// FailureThresholdPercent is the percentage of failed tasks, out of all tasks, at or above
+	// which jobRunner.Run returns an error. Zero (the default) preserves the original behavior of
+	// only failing the job when every task fails.
+	FailureThresholdPercent int
+	// StopOnFirstFailure cancels the worker pool's context as soon as any task fails, so idle
+	// workers stop picking up new tasks instead of running them to completion.
+	StopOnFirstFailure bool
+}
+
+// PolicyProvider is implemented by jobs that want to customize the JobPolicy used by jobRunner.Run.
+// Jobs that don't implement it get the default policy: no per-task timeout, a 100% failure
+// threshold, and no early stop on first failure.
+type PolicyProvider interface {
+	Job
+	Policy() JobPolicy
+}
+
+// jobPolicy resolves the JobPolicy for job, falling back to the default policy when job does not
+// implement PolicyProvider or leaves FailureThresholdPercent unset.
+func jobPolicy(job Job) JobPolicy {
+	policy := JobPolicy{FailureThresholdPercent: 100}
+	if provider, ok := job.(PolicyProvider); ok {
+		policy = provider.Policy()
+		if policy.FailureThresholdPercent <= 0 {
+			policy.FailureThresholdPercent = 100
+		}
+	}
+	return policy
+}
+
+// exceedsFailureThreshold reports whether the collector's current failure rate is at or above
+// thresholdPercent.
+func exceedsFailureThreshold(collector *MetricsCollector, thresholdPercent int) bool {
+	if collector.taskTotal == 0 {
+		return false
+	}
+	failurePercent := float64(collector.taskFailed) / float64(collector.taskTotal) * 100
+	return failurePercent >= float64(thresholdPercent)
+}
+
 // CommandBuilder builds a Cobra CLI command that wraps registered jobs.
 //
 // It supports optional lifecycle hooks and task wrappers for additional behavior.
@@ -97,11 +146,22 @@ func (b *CommandBuilder) Build() *cobra.Command {
 				if b.metricsReporter == nil {
 					b.metricsReporter = NewStdoutReporter()
 				}
-				err = jobRunner{BeforeJob: b.beforeJob, AfterJob: b.afterJob, PanicHandler: b.panicHandler, MetricsReporter: b.metricsReporter}.Run(b.ctx, job, job.GetWorkerCount())
-				if err != nil {
-					return err
+
+				jr := jobRunner{BeforeJob: b.beforeJob, AfterJob: b.afterJob, PanicHandler: b.panicHandler, MetricsReporter: b.metricsReporter}
+
+				if stdinJob, ok := job.(StdinTasks); ok && stdinHasData(cmd.InOrStdin()) {
+					tasks, decodeErr := stdinJob.TasksFromStdin(json.NewDecoder(cmd.InOrStdin()))
+					if decodeErr != nil {
+						return decodeErr
+					}
+					// Left unwrapped here: Run applies WithRetry (when the job is a RetryableJob)
+					// before wrapping in WithResultEncoding below, so a result line is only written
+					// once the task's retries are exhausted, not once per attempt.
+					jr.Tasks = tasks
+					jr.ResultEncoder = json.NewEncoder(cmd.OutOrStdout())
 				}
-				return nil
+
+				return jr.Run(b.ctx, job, job.GetWorkerCount())
 			},
 		}
 		job.AddFlags(subCmd.Flags())
@@ -118,6 +178,23 @@ func validateJob(job Job) error {
 	return nil
 }
 
+// stdinHasData reports whether r looks like a real input stream with data piped in, rather than
+// an interactive terminal with nothing to read. Used to decide whether a StdinTasks job should
+// decode a batch from stdin or fall back to building tasks from the environment via GetTasks.
+func stdinHasData(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		// Not a real file (e.g. a caller-supplied reader in tests) -- assume it was wired up
+		// deliberately and has data.
+		return true
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
+
 type JobRegistry struct {
 	jobs []Job
 }
@@ -139,84 +216,61 @@ type Task interface {
 	TaskName() string
 }
 
-// taskQueue is a thread-safe FIFO queue of tasks.
-type taskQueue struct {
-	Tasks []Task
-	mu    sync.Mutex
-}
-
-// Add appends a task to the queue.
-//
-// Note: This method is not thread-safe unless used with external synchronization.
-func (q *taskQueue) Add(task Task) {
-	q.Tasks = append(q.Tasks, task)
-}
-
-func (q *taskQueue) GetTask() Task {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	if len(q.Tasks) == 0 {
-		return nil
-	}
-	task := q.Tasks[0]
-	q.Tasks = q.Tasks[1:]
-	return task
-}
-
-func newTaskQueue() *taskQueue {
-	return &taskQueue{mu: sync.Mutex{}}
-}
-
-// WorkerPool runs a fixed number of workers to process tasks from a queue.
+// workerPool runs a fixed number of workers to process tasks, dispatching by task index over a
+// buffered channel rather than a mutex-guarded queue. The producer enqueues every index up front
+// and closes the channel; workers range over it until it's drained or ctx is canceled, so there is
+// no per-task lock contention.
 type workerPool struct {
-	Queue            *taskQueue
-	Workers          int
-	PanicHandler     func(ctx context.Context, any interface{})
-	MetricsCollector *MetricsCollector
+	Tasks              []Task
+	Workers            int
+	PanicHandler       func(ctx context.Context, any interface{})
+	MetricsCollector   *MetricsCollector
+	TaskTimeout        time.Duration
+	StopOnFirstFailure bool
 }
 
-// Run starts the worker pool and processes tasks until the queue is empty.
+// Run starts the worker pool and processes every task, or until ctx is canceled.
 func (wp *workerPool) Run(ctx context.Context) {
 	ulog := logger.NewOCMLogger(ctx)
-	var wg sync.WaitGroup
 
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indices := make(chan int, len(wp.Tasks))
+	for i := range wp.Tasks {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
 	for i := 0; i < wp.Workers; i++ {
 		wg.Add(1)
 		go func(workerId int) {
 			defer wg.Done()
 			for {
-				task := wp.Queue.GetTask()
-				if task == nil {
-					// No more tasks left
-					ulog.Info("No more tasks in queue")
+				// Check cancellation before pulling the next index so a StopOnFirstFailure
+				// cancellation is honored even though the indices channel is already
+				// buffered full and would otherwise win a random select.
+				select {
+				case <-runCtx.Done():
+					ulog.Info("Context canceled, stopping dispatch")
 					return
+				default:
 				}
-				func() {
-					taskId := ksuid.New().String()
-
-					taskCtx := AddTraceContext(ctx, "workerId", strconv.Itoa(workerId))
-					taskCtx = AddTraceContext(taskCtx, "taskName", task.TaskName())
-					taskCtx = AddTraceContext(taskCtx, "taskId", taskId)
-
-					defer func(taskCtx context.Context) {
-						if err := recover(); err != nil {
-							wp.MetricsCollector.IncTaskFailed()
-							logger.NewOCMLogger(taskCtx).Contextual().Error(fmt.Errorf("<panic summary should go here>"), fmt.Sprintf("[Task %s] Panic", task.TaskName()), "exception", err)
-							if wp.PanicHandler != nil {
-								wp.PanicHandler(taskCtx, err)
-							}
-						}
-					}(taskCtx)
-
-					logger.NewOCMLogger(taskCtx).Contextual().Info("Processing task", "workerId", workerId, "taskId", taskId)
-					err := task.Process(taskCtx)
-					if err != nil {
-						wp.MetricsCollector.IncTaskFailed()
-						logger.NewOCMLogger(ctx).Contextual().Error(err, fmt.Sprintf("[Task %s] Failed", task.TaskName()))
-					} else {
-						wp.MetricsCollector.IncTaskSuccess()
+
+				select {
+				case <-runCtx.Done():
+					ulog.Info("Context canceled, stopping dispatch")
+					return
+				case idx, ok := <-indices:
+					if !ok {
+						// No more tasks left
+						return
+					}
+					if !wp.process(runCtx, workerId, wp.Tasks[idx]) && wp.StopOnFirstFailure {
+						cancel()
 					}
-				}()
+				}
 			}
 		}(i)
 	}
@@ -224,6 +278,50 @@ func (wp *workerPool) Run(ctx context.Context) {
 	wg.Wait()
 }
 
+// process runs a single task, recovering from panics and recording its outcome. It reports
+// whether the task succeeded so Run can decide whether to stop the pool early.
+func (wp *workerPool) process(ctx context.Context, workerId int, task Task) (success bool) {
+	taskId := ksuid.New().String()
+
+	taskCtx := AddTraceContext(ctx, "workerId", strconv.Itoa(workerId))
+	taskCtx = AddTraceContext(taskCtx, "taskName", task.TaskName())
+	taskCtx = AddTraceContext(taskCtx, "taskId", taskId)
+	taskCtx = WithTaskLogger(taskCtx, NewTaskLogger(taskCtx))
+
+	if wp.TaskTimeout > 0 {
+		var cancel context.CancelFunc
+		taskCtx, cancel = context.WithTimeout(taskCtx, wp.TaskTimeout)
+		defer cancel()
+	}
+
+	defer func(taskCtx context.Context) {
+		if err := recover(); err != nil {
+			success = false
+			wp.MetricsCollector.IncTaskFailed()
+			logger.NewOCMLogger(taskCtx).Contextual().Error(fmt.Errorf("<panic summary should go here>"), fmt.Sprintf("[Task %s] Panic", task.TaskName()), "exception", err)
+			if wp.PanicHandler != nil {
+				wp.PanicHandler(taskCtx, err)
+			}
+		}
+	}(taskCtx)
+
+	logger.NewOCMLogger(taskCtx).Contextual().Info("Processing task", "workerId", workerId, "taskId", taskId)
+	startTime := time.Now()
+	err := task.Process(taskCtx)
+	wp.MetricsCollector.ObserveTaskDuration(task.TaskName(), time.Since(startTime))
+	if err != nil {
+		if wp.TaskTimeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+			wp.MetricsCollector.IncTaskTimeout()
+		}
+		wp.MetricsCollector.IncTaskFailed()
+		logger.NewOCMLogger(taskCtx).Contextual().Error(err, fmt.Sprintf("[Task %s] Failed", task.TaskName()))
+		return false
+	}
+
+	wp.MetricsCollector.IncTaskSuccess()
+	return true
+}
+
 type runner interface {
 	Run(context.Context, Job, int) error
 }
@@ -237,13 +335,21 @@ type jobRunner struct {
 	AfterJob        func(ctx context.Context)
 	PanicHandler    func(ctx context.Context, any interface{})
 	MetricsReporter MetricsReporter
+	// Tasks, when set, is used instead of calling job.GetTasks() -- e.g. a batch decoded from
+	// stdin by a StdinTasks job.
+	Tasks []Task
+	// ResultEncoder, when set, wraps every task in WithResultEncoding once retry wrapping (if any)
+	// has already been applied, so a caller that piped tasks in via StdinTasks gets exactly one
+	// TaskResult per task -- written after retries are exhausted, not once per attempt.
+	ResultEncoder  *json.Encoder
+	resultEncodeMu sync.Mutex
 }
 
 // Run executes the given job using a worker pool.
 //
 // It first invokes the BeforeJob hook (if defined). Then, it enqueues all tasks and delegates to worker pool for execution.
 // After all tasks are processed, the AfterJob hook is called.
-func (jr jobRunner) Run(ctx context.Context, job Job, workerCount int) error {
+func (jr *jobRunner) Run(ctx context.Context, job Job, workerCount int) error {
 	ctx = AddTraceContext(ctx, "jobName", job.GetMetadata().Use)
 	ctx = AddTraceContext(ctx, "jobId", ksuid.New().String())
 	ulog := logger.NewOCMLogger(ctx)
@@ -272,25 +378,66 @@ func (jr jobRunner) Run(ctx context.Context, job Job, workerCount int) error {
 		}
 	}
 
-	taskTotal := 0
-	taskQueue := newTaskQueue()
-
-	tasks, err := job.GetTasks()
+	var tasks []Task
+	if jr.Tasks != nil {
+		tasks = jr.Tasks
+	} else {
+		var err error
+		tasks, err = job.GetTasks()
+		if err != nil {
+			ulog.Contextual().Error(err, fmt.Sprintf("[Job %s] Error getting tasks", job.GetMetadata().Use))
+			return err
+		}
+	}
+	metricsCollector := NewMetricsCollector(job.GetMetadata().Use)
 
-	if err != nil {
-		ulog.Contextual().Error(err, fmt.Sprintf("[Job %s] Error getting tasks", job.GetMetadata().Use))
-		return err
+	if retryableJob, ok := job.(RetryableJob); ok {
+		policy := retryableJob.DefaultRetryPolicy()
+		for i, task := range tasks {
+			tasks[i] = WithRetry(task, policy, metricsCollector)
+		}
 	}
-	for _, task := range tasks {
-		taskQueue.Add(task)
-		taskTotal += 1
+
+	// ResultEncoder must wrap the (possibly retry-wrapped) task, not the other way around, so a
+	// TaskResult is only written once a task's retries are exhausted rather than once per attempt.
+	if jr.ResultEncoder != nil {
+		for i, task := range tasks {
+			tasks[i] = WithResultEncoding(task, jr.ResultEncoder, &jr.resultEncodeMu)
+		}
 	}
-	metricsCollector := NewMetricsCollector(job.GetMetadata().Use)
-	metricsCollector.SetTaskTotal(uint32(taskTotal))
+
+	metricsCollector.SetTaskTotal(uint32(len(tasks)))
+
+	policy := jobPolicy(job)
 
 	ulog.Contextual().Info("queued all the tasks")
 
-	pool := workerPool{Queue: taskQueue, Workers: workerCount, PanicHandler: jr.PanicHandler, MetricsCollector: metricsCollector}
+	if periodic, ok := jr.MetricsReporter.(*PeriodicReporter); ok && periodic.Interval > 0 {
+		stopPeriodicReport := make(chan struct{})
+		defer close(stopPeriodicReport)
+
+		go func() {
+			ticker := time.NewTicker(periodic.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					periodic.Report(metricsCollector)
+				case <-stopPeriodicReport:
+					return
+				}
+			}
+		}()
+	}
+
+	pool := workerPool{
+		Tasks:              tasks,
+		Workers:            workerCount,
+		PanicHandler:       jr.PanicHandler,
+		MetricsCollector:   metricsCollector,
+		TaskTimeout:        policy.TaskTimeout,
+		StopOnFirstFailure: policy.StopOnFirstFailure,
+	}
 	pool.Run(ctx)
 
 	if jr.AfterJob != nil {
@@ -298,8 +445,8 @@ func (jr jobRunner) Run(ctx context.Context, job Job, workerCount int) error {
 		jr.AfterJob(ctx)
 	}
 
-	// For now, we report metrics only once at the end. In the future, we may need to support periodic reporting or
-	// synchronous updates (e.g., when counters are modified) to integrate with push-based systems like Prometheus Pushgateway.
+	// Always report once more at the end so the final state (including any tasks that finished after
+	// the last periodic tick) is reflected.
 	jr.MetricsReporter.Report(metricsCollector)
 
 	if metricsCollector.taskTotal == 0 {
@@ -307,10 +454,8 @@ func (jr jobRunner) Run(ctx context.Context, job Job, workerCount int) error {
 		ulog.Contextual().Info("No tasks to run!")
 		return nil
 	}
-	// For now return error when all tasks fail. This can be configurable for e.g. return error when 80% of tasks fail.
-	if metricsCollector.taskFailed == metricsCollector.taskTotal {
-		err := errors.New("all tasks failed")
-		return err
+	if exceedsFailureThreshold(metricsCollector, policy.FailureThresholdPercent) {
+		return fmt.Errorf("%d out of %d tasks failed, meeting or exceeding the %d%% failure threshold", metricsCollector.taskFailed, metricsCollector.taskTotal, policy.FailureThresholdPercent)
 	}
 
 	ulog.Contextual().Info("job executed successfully")
@@ -321,27 +466,27 @@ func (jr jobRunner) Run(ctx context.Context, job Job, workerCount int) error {
 type TestRunner struct{}
 
 func (tr TestRunner) Run(ctx context.Context, job Job, workerCount int) error {
-	taskTotal := 0
-	taskQueue := newTaskQueue()
-
 	tasks, err := job.GetTasks()
 
 	if err != nil {
 		return err
 	}
-	for _, task := range tasks {
-		taskQueue.Add(task)
-		taskTotal += 1
-	}
 	metricsCollector := NewMetricsCollector(job.GetMetadata().Use)
-	metricsCollector.SetTaskTotal(uint32(taskTotal))
-
-	pool := workerPool{Queue: taskQueue, Workers: workerCount, PanicHandler: nil, MetricsCollector: metricsCollector}
+	metricsCollector.SetTaskTotal(uint32(len(tasks)))
+
+	policy := jobPolicy(job)
+	pool := workerPool{
+		Tasks:              tasks,
+		Workers:            workerCount,
+		PanicHandler:       nil,
+		MetricsCollector:   metricsCollector,
+		TaskTimeout:        policy.TaskTimeout,
+		StopOnFirstFailure: policy.StopOnFirstFailure,
+	}
 	pool.Run(ctx)
 
-	if metricsCollector.taskFailed == metricsCollector.taskTotal {
-		err := errors.New("all tasks failed")
-		return err
+	if exceedsFailureThreshold(metricsCollector, policy.FailureThresholdPercent) {
+		return fmt.Errorf("%d out of %d tasks failed, meeting or exceeding the %d%% failure threshold", metricsCollector.taskFailed, metricsCollector.taskTotal, policy.FailureThresholdPercent)
 	}
 	return nil
 }
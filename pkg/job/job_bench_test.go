@@ -0,0 +1,32 @@
+package job
+
+import (
+	"context"
+	"testing"
+)
+
+// noopTask is a zero-cost Task used to isolate dispatch overhead in the worker pool benchmarks.
+type noopTask struct{}
+
+func (noopTask) TaskName() string                  { return "noop" }
+func (noopTask) Process(ctx context.Context) error { return nil }
+
+func benchmarkWorkerPool(b *testing.B, taskCount, workers int) {
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		tasks := make([]Task, taskCount)
+		for j := range tasks {
+			tasks[j] = noopTask{}
+		}
+
+		pool := workerPool{Tasks: tasks, Workers: workers, MetricsCollector: NewMetricsCollector("bench")}
+		pool.Run(ctx)
+	}
+}
+
+func BenchmarkWorkerPool_100k_1Worker(b *testing.B)  { benchmarkWorkerPool(b, 100_000, 1) }
+func BenchmarkWorkerPool_100k_8Workers(b *testing.B) { benchmarkWorkerPool(b, 100_000, 8) }
+func BenchmarkWorkerPool_100k_64Workers(b *testing.B) {
+	benchmarkWorkerPool(b, 100_000, 64)
+}
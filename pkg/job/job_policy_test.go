@@ -0,0 +1,125 @@
+package job
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type policyFakeTask struct {
+	name    string
+	process func(ctx context.Context) error
+}
+
+func (t *policyFakeTask) TaskName() string                  { return t.name }
+func (t *policyFakeTask) Process(ctx context.Context) error { return t.process(ctx) }
+
+func TestWorkerPool_TaskTimeout_CountsAsFailureAndTimeout(t *testing.T) {
+	metricsCollector := NewMetricsCollector("test-job")
+	task := &policyFakeTask{name: "slow", process: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	pool := workerPool{
+		Tasks:            []Task{task},
+		Workers:          1,
+		MetricsCollector: metricsCollector,
+		TaskTimeout:      10 * time.Millisecond,
+	}
+	pool.Run(context.Background())
+
+	snapshot := metricsCollector.Snapshot()
+	if snapshot.taskFailed != 1 {
+		t.Errorf("expected 1 failed task, got %d", snapshot.taskFailed)
+	}
+	if snapshot.taskTimeout != 1 {
+		t.Errorf("expected 1 timed-out task, got %d", snapshot.taskTimeout)
+	}
+}
+
+func TestWorkerPool_StopOnFirstFailure_CancelsRemainingTasks(t *testing.T) {
+	metricsCollector := NewMetricsCollector("test-job")
+	var started int32
+
+	tasks := []Task{
+		&policyFakeTask{name: "fails", process: func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			return errRetryable
+		}},
+		&policyFakeTask{name: "never-runs-1", process: func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			return nil
+		}},
+		&policyFakeTask{name: "never-runs-2", process: func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			return nil
+		}},
+	}
+
+	pool := workerPool{
+		Tasks:              tasks,
+		Workers:            1,
+		MetricsCollector:   metricsCollector,
+		StopOnFirstFailure: true,
+	}
+	pool.Run(context.Background())
+
+	if got := atomic.LoadInt32(&started); got != 1 {
+		t.Errorf("expected exactly 1 task to start before the pool stopped, got %d", got)
+	}
+}
+
+func TestExceedsFailureThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     uint32
+		failed    uint32
+		threshold int
+		expected  bool
+	}{
+		{"no tasks never exceeds", 0, 0, 100, false},
+		{"all fail at 100 threshold", 4, 4, 100, true},
+		{"half fail at 100 threshold", 4, 2, 100, false},
+		{"exactly at 80 threshold", 5, 4, 80, true},
+		{"below 80 threshold", 5, 3, 80, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := NewMetricsCollector("test-job")
+			collector.SetTaskTotal(tt.total)
+			for i := uint32(0); i < tt.failed; i++ {
+				collector.IncTaskFailed()
+			}
+			if got := exceedsFailureThreshold(collector, tt.threshold); got != tt.expected {
+				t.Errorf("exceedsFailureThreshold() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+type policyFakeJob struct {
+	policy JobPolicy
+}
+
+func (j policyFakeJob) GetMetadata() Metadata         { return Metadata{Use: "policy-fake"} }
+func (j policyFakeJob) AddFlags(flags *pflag.FlagSet) {}
+func (j policyFakeJob) GetTasks() ([]Task, error)     { return nil, nil }
+func (j policyFakeJob) GetWorkerCount() int           { return 1 }
+func (j policyFakeJob) Policy() JobPolicy             { return j.policy }
+
+func TestJobPolicy_DefaultsTo100PercentThreshold(t *testing.T) {
+	j := policyFakeJob{policy: JobPolicy{StopOnFirstFailure: true}}
+	resolved := jobPolicy(j)
+
+	if resolved.FailureThresholdPercent != 100 {
+		t.Errorf("expected default FailureThresholdPercent 100, got %d", resolved.FailureThresholdPercent)
+	}
+	if !resolved.StopOnFirstFailure {
+		t.Error("expected StopOnFirstFailure to be preserved from Policy()")
+	}
+}
@@ -3,6 +3,7 @@ package job
 import (
 	"context"
 	"sync"
+	"time"
 
 	logger "github.com/openshift-online/ocm-service-common/pkg/ocmlogger"
 )
@@ -14,15 +15,18 @@ type MetricsReporter interface {
 // MetricsCollector uses locking to ensure we get point-in-time snapshot of the whole data. This snapshot data will be
 // then used to report metrics.
 type MetricsCollector struct {
-	mu          sync.Mutex
-	jobName     string
-	taskTotal   uint32
-	taskSuccess uint32
-	taskFailed  uint32
+	mu            sync.Mutex
+	jobName       string
+	taskTotal     uint32
+	taskSuccess   uint32
+	taskFailed    uint32
+	taskRetried   uint32
+	taskTimeout   uint32
+	taskDurations map[string][]time.Duration
 }
 
 func NewMetricsCollector(jobName string) *MetricsCollector {
-	return &MetricsCollector{jobName: jobName}
+	return &MetricsCollector{jobName: jobName, taskDurations: make(map[string][]time.Duration)}
 }
 
 func (m *MetricsCollector) SetTaskTotal(total uint32) {
@@ -38,16 +42,42 @@ func (m *MetricsCollector) IncTaskFailed() {
 	m.taskFailed++
 	m.mu.Unlock()
 }
+func (m *MetricsCollector) IncTaskRetried() {
+	m.mu.Lock()
+	m.taskRetried++
+	m.mu.Unlock()
+}
+func (m *MetricsCollector) IncTaskTimeout() {
+	m.mu.Lock()
+	m.taskTimeout++
+	m.mu.Unlock()
+}
+
+// ObserveTaskDuration records how long a single Task.Process call for the named task took, so
+// reporters can build per-task duration histograms.
+func (m *MetricsCollector) ObserveTaskDuration(name string, d time.Duration) {
+	m.mu.Lock()
+	m.taskDurations[name] = append(m.taskDurations[name], d)
+	m.mu.Unlock()
+}
 
 func (m *MetricsCollector) Snapshot() MetricsCollector {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	taskDurations := make(map[string][]time.Duration, len(m.taskDurations))
+	for name, durations := range m.taskDurations {
+		taskDurations[name] = append([]time.Duration(nil), durations...)
+	}
+
 	return MetricsCollector{
-		jobName:     m.jobName,
-		taskTotal:   m.taskTotal,
-		taskSuccess: m.taskSuccess,
-		taskFailed:  m.taskFailed,
+		jobName:       m.jobName,
+		taskTotal:     m.taskTotal,
+		taskSuccess:   m.taskSuccess,
+		taskFailed:    m.taskFailed,
+		taskRetried:   m.taskRetried,
+		taskTimeout:   m.taskTimeout,
+		taskDurations: taskDurations,
 	}
 
 }
@@ -58,9 +88,26 @@ type StdoutReporter struct {
 func (r StdoutReporter) Report(metricsCollector *MetricsCollector) {
 	// use snapshot for point-in-time data
 	snapshot := metricsCollector.Snapshot()
-	logger.NewOCMLogger(context.Background()).Contextual().Info("Printing metrics to STDOUT", "task_total", snapshot.taskTotal, "task_success", snapshot.taskSuccess, "task_failed", snapshot.taskFailed)
+	logger.NewOCMLogger(context.Background()).Contextual().Info("Printing metrics to STDOUT", "task_total", snapshot.taskTotal, "task_success", snapshot.taskSuccess, "task_failed", snapshot.taskFailed, "task_retried", snapshot.taskRetried, "task_timeout", snapshot.taskTimeout)
 }
 
 func NewStdoutReporter() MetricsReporter {
 	return StdoutReporter{}
 }
+
+// PeriodicReporter wraps another MetricsReporter so jobRunner.Run can push intermediate progress
+// on a fixed interval, rather than only once after all tasks finish. This matters for long-running
+// jobs where a single end-of-job report gives no visibility while the job is still in flight.
+type PeriodicReporter struct {
+	Reporter MetricsReporter
+	Interval time.Duration
+}
+
+// NewPeriodicReporter wraps reporter so it is also invoked every interval while a job is running.
+func NewPeriodicReporter(reporter MetricsReporter, interval time.Duration) *PeriodicReporter {
+	return &PeriodicReporter{Reporter: reporter, Interval: interval}
+}
+
+func (r *PeriodicReporter) Report(metricsCollector *MetricsCollector) {
+	r.Reporter.Report(metricsCollector)
+}
@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+
+	logger "github.com/openshift-online/ocm-service-common/pkg/ocmlogger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// PushgatewayReporter reports job metrics to a Prometheus Pushgateway, grouping by job name so
+// successive pushes for the same job overwrite rather than accumulate.
+type PushgatewayReporter struct {
+	PushURL string
+}
+
+func NewPushgatewayReporter(pushURL string) *PushgatewayReporter {
+	return &PushgatewayReporter{PushURL: pushURL}
+}
+
+func (r *PushgatewayReporter) Report(metricsCollector *MetricsCollector) {
+	snapshot := metricsCollector.Snapshot()
+
+	taskTotal := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "task_total",
+		Help: "Total number of tasks queued for the job.",
+	})
+	taskSuccess := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "task_success",
+		Help: "Number of tasks that completed successfully.",
+	})
+	taskFailed := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "task_failed",
+		Help: "Number of tasks that failed.",
+	})
+	taskRetried := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "task_retried",
+		Help: "Number of retry attempts made across all tasks.",
+	})
+	taskTimeout := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "task_timeout",
+		Help: "Number of tasks that were canceled for exceeding their TaskTimeout.",
+	})
+	taskDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "task_duration_seconds",
+		Help:    "Duration of individual Task.Process calls.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"task"})
+
+	taskTotal.Set(float64(snapshot.taskTotal))
+	taskSuccess.Set(float64(snapshot.taskSuccess))
+	taskFailed.Set(float64(snapshot.taskFailed))
+	taskRetried.Set(float64(snapshot.taskRetried))
+	taskTimeout.Set(float64(snapshot.taskTimeout))
+	for name, durations := range snapshot.taskDurations {
+		for _, d := range durations {
+			taskDuration.WithLabelValues(name).Observe(d.Seconds())
+		}
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(taskTotal, taskSuccess, taskFailed, taskRetried, taskTimeout, taskDuration)
+
+	// push.New's second argument already sets the "job" grouping key; calling Grouping("job", ...)
+	// again is rejected by the Pushgateway since "job" is reserved.
+	err := push.New(r.PushURL, snapshot.jobName).
+		Gatherer(registry).
+		Push()
+	if err != nil {
+		logger.NewOCMLogger(context.Background()).Contextual().Error(err, "failed to push metrics to Pushgateway", "push_url", r.PushURL, "job_name", snapshot.jobName)
+	}
+}
@@ -0,0 +1,45 @@
+package job
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestPushgatewayReporter_Report_PushesExactlyOnceWithoutDuplicateGrouping guards against a
+// regression where Grouping("job", ...) was called on top of push.New's own "job" grouping key,
+// which the Pushgateway rejects as a reserved label -- causing every push to fail silently.
+func TestPushgatewayReporter_Report_PushesExactlyOnceWithoutDuplicateGrouping(t *testing.T) {
+	var requests int
+	var path string
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	collector := NewMetricsCollector("test-job")
+	collector.SetTaskTotal(3)
+	collector.IncTaskSuccess()
+	collector.IncTaskFailed()
+
+	reporter := NewPushgatewayReporter(server.URL)
+	reporter.Report(collector)
+
+	if requests != 1 {
+		t.Fatalf("expected exactly 1 push request, got %d", requests)
+	}
+	if !strings.Contains(path, "test-job") {
+		t.Errorf("expected the push path to be grouped under the job name, got %q", path)
+	}
+	if !strings.Contains(body, "task_total") {
+		t.Errorf("expected the pushed body to contain the task_total metric, got %q", body)
+	}
+}
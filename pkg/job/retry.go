@@ -0,0 +1,164 @@
+package job
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// retryDeadlineSafetyMargin is subtracted from ctx's remaining time before deciding whether there
+// is enough budget left to sleep out another backoff. It leaves headroom for the retried call
+// itself to run (and fail) before ctx's deadline arrives.
+const retryDeadlineSafetyMargin = 100 * time.Millisecond
+
+// RetryPolicy configures the exponential backoff applied by WithRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times Process is called, including the first attempt.
+	MaxAttempts int
+	// BaseBackoff is the backoff duration used for the first retry.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the backoff duration, before jitter is applied.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the backoff grows by on each successive attempt. Defaults to 2 when
+	// zero.
+	Multiplier float64
+	// JitterFraction is the fraction (e.g. 0.2 for ±20%) of the backoff duration randomized on each
+	// retry. Ignored when FullJitter is true.
+	JitterFraction float64
+	// FullJitter switches to the AWS-style full-jitter formula (sleep = rand(0, backoff)) instead of
+	// JitterFraction's ±fraction jitter.
+	FullJitter bool
+	// MaxElapsed bounds the total wall-clock time spent retrying, measured from the first attempt.
+	// Once the next backoff would cross this budget, WithRetry gives up and returns the last error
+	// instead of sleeping. Zero means unbounded.
+	MaxElapsed time.Duration
+	// BackoffMultiplierFunc, when set, scales the computed backoff for a given error - for example,
+	// backing off more aggressively for a resource-exhaustion-like error than for a plain
+	// unavailability error. A return value <= 0 leaves the backoff unscaled.
+	BackoffMultiplierFunc func(error) float64
+	// RetryableFunc reports whether an error returned by Process should be retried. A nil
+	// RetryableFunc treats every error as retryable.
+	RetryableFunc func(error) bool
+}
+
+// retryable reports whether err should be retried under this policy.
+func (p RetryPolicy) retryable(err error) bool {
+	if p.RetryableFunc == nil {
+		return true
+	}
+	return p.RetryableFunc(err)
+}
+
+// backoff computes the sleep duration before the given retry attempt (1-indexed) for err, with
+// jitter applied.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	base := float64(p.BaseBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.BackoffMultiplierFunc != nil {
+		if m := p.BackoffMultiplierFunc(err); m > 0 {
+			base *= m
+		}
+	}
+	if p.MaxBackoff > 0 && base > float64(p.MaxBackoff) {
+		base = float64(p.MaxBackoff)
+	}
+	capped := time.Duration(base)
+
+	if p.FullJitter {
+		if capped <= 0 {
+			return 0
+		}
+		return time.Duration(rand.Int63n(int64(capped) + 1))
+	}
+
+	if p.JitterFraction <= 0 {
+		return capped
+	}
+	jitterRange := float64(capped) * p.JitterFraction
+	jitter := time.Duration((rand.Float64()*2 - 1) * jitterRange)
+	return capped + jitter
+}
+
+// retryTask decorates a Task with exponential backoff retries governed by a RetryPolicy.
+type retryTask struct {
+	Task
+	policy           RetryPolicy
+	metricsCollector *MetricsCollector
+}
+
+// WithRetry wraps task so that Process is retried with exponential backoff and jitter according to
+// policy, honoring ctx.Done() between attempts and giving up early rather than sleeping past ctx's
+// deadline or policy.MaxElapsed. If metricsCollector is non-nil, every retry increments its
+// retried-task counter.
+func WithRetry(task Task, policy RetryPolicy, metricsCollector *MetricsCollector) Task {
+	return retryTask{Task: task, policy: policy, metricsCollector: metricsCollector}
+}
+
+func (t retryTask) Process(ctx context.Context) error {
+	maxAttempts := t.policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	start := time.Now()
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = t.Task.Process(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if !t.policy.retryable(err) {
+			return err
+		}
+
+		if attempt == maxAttempts {
+			break
+		}
+
+		backoff := t.policy.backoff(attempt, err)
+
+		// A client-side context deadline is not worth retrying into: if there isn't enough budget
+		// left for another attempt, give up now instead of sleeping toward a doomed retry. This is
+		// distinct from a *server-reported* DeadlineExceeded-style error, which RetryableFunc
+		// classifies on its own merits above.
+		if deadline, ok := ctx.Deadline(); ok {
+			remaining := time.Until(deadline) - retryDeadlineSafetyMargin
+			if remaining < t.policy.BaseBackoff {
+				return err
+			}
+			if backoff > remaining {
+				backoff = remaining
+			}
+		}
+		if t.policy.MaxElapsed > 0 && time.Since(start)+backoff > t.policy.MaxElapsed {
+			return err
+		}
+
+		if t.metricsCollector != nil {
+			t.metricsCollector.IncTaskRetried()
+		}
+
+		TaskLoggerFromContext(ctx).Op("retry").With("attempt", attempt, "backoff_ms", backoff.Milliseconds()).Error(err, "task failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+	return err
+}
+
+// RetryableJob is an optional extension of Job for jobs that want every task they enqueue wrapped
+// in WithRetry using a shared default policy.
+type RetryableJob interface {
+	Job
+	DefaultRetryPolicy() RetryPolicy
+}
@@ -0,0 +1,201 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeTask struct {
+	name    string
+	process func(ctx context.Context) error
+}
+
+func (t *fakeTask) TaskName() string { return t.name }
+func (t *fakeTask) Process(ctx context.Context) error {
+	return t.process(ctx)
+}
+
+var errNonRetryable = errors.New("non-retryable")
+var errRetryable = errors.New("retryable")
+
+func alwaysRetryable(err error) bool {
+	return !errors.Is(err, errNonRetryable)
+}
+
+func TestWithRetry_NonRetryableStopsImmediately(t *testing.T) {
+	attempts := 0
+	task := &fakeTask{name: "t", process: func(ctx context.Context) error {
+		attempts++
+		return errNonRetryable
+	}}
+
+	wrapped := WithRetry(task, RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryableFunc: alwaysRetryable}, nil)
+	err := wrapped.Process(context.Background())
+
+	if !errors.Is(err, errNonRetryable) {
+		t.Errorf("expected errNonRetryable, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestWithRetry_ExhaustsRetries(t *testing.T) {
+	attempts := 0
+	task := &fakeTask{name: "t", process: func(ctx context.Context) error {
+		attempts++
+		return errRetryable
+	}}
+
+	metricsCollector := NewMetricsCollector("test-job")
+	wrapped := WithRetry(task, RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryableFunc: alwaysRetryable}, metricsCollector)
+	err := wrapped.Process(context.Background())
+
+	if !errors.Is(err, errRetryable) {
+		t.Errorf("expected errRetryable, got: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if snapshot := metricsCollector.Snapshot(); snapshot.taskRetried != 2 {
+		t.Errorf("expected 2 recorded retries, got %d", snapshot.taskRetried)
+	}
+}
+
+func TestWithRetry_ContextCancelledMidBackoff(t *testing.T) {
+	attempts := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &fakeTask{name: "t", process: func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			cancel()
+		}
+		return errRetryable
+	}}
+
+	wrapped := WithRetry(task, RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Second, RetryableFunc: alwaysRetryable}, nil)
+	err := wrapped.Process(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt before cancellation took effect, got %d", attempts)
+	}
+}
+
+func TestRetryPolicy_BackoffRespectsJitterBounds(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, JitterFraction: 0.2}
+
+	for attempt := 1; attempt <= 4; attempt++ {
+		base := policy.BaseBackoff << uint(attempt-1)
+		if base > policy.MaxBackoff {
+			base = policy.MaxBackoff
+		}
+		minBound := time.Duration(float64(base) * 0.8)
+		maxBound := time.Duration(float64(base) * 1.2)
+
+		for i := 0; i < 20; i++ {
+			backoff := policy.backoff(attempt, nil)
+			if backoff < minBound || backoff > maxBound {
+				t.Errorf("attempt %d: backoff %s out of jitter bounds [%s, %s]", attempt, backoff, minBound, maxBound)
+			}
+		}
+	}
+}
+
+func TestRetryPolicy_FullJitterStaysWithinZeroToCap(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, FullJitter: true}
+
+	for attempt := 1; attempt <= 4; attempt++ {
+		upperBound := policy.BaseBackoff << uint(attempt-1)
+		if upperBound > policy.MaxBackoff {
+			upperBound = policy.MaxBackoff
+		}
+
+		for i := 0; i < 50; i++ {
+			backoff := policy.backoff(attempt, nil)
+			if backoff < 0 || backoff > upperBound {
+				t.Errorf("attempt %d: full-jitter backoff %s out of bounds [0, %s]", attempt, backoff, upperBound)
+			}
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffMultiplierFuncScalesPerError(t *testing.T) {
+	errResourceExhausted := errors.New("resource exhausted")
+
+	policy := RetryPolicy{
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		BackoffMultiplierFunc: func(err error) float64 {
+			if errors.Is(err, errResourceExhausted) {
+				return 5
+			}
+			return 1
+		},
+	}
+
+	plain := policy.backoff(1, errRetryable)
+	aggressive := policy.backoff(1, errResourceExhausted)
+
+	if aggressive <= plain {
+		t.Errorf("expected resource-exhausted backoff (%s) to exceed the default backoff (%s)", aggressive, plain)
+	}
+	if aggressive != 500*time.Millisecond {
+		t.Errorf("expected a 5x backoff of 500ms, got %s", aggressive)
+	}
+}
+
+func TestWithRetry_GivesUpEarlyWhenContextDeadlineTooShort(t *testing.T) {
+	attempts := 0
+	task := &fakeTask{name: "t", process: func(ctx context.Context) error {
+		attempts++
+		return errRetryable
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	wrapped := WithRetry(task, RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Second, RetryableFunc: alwaysRetryable}, nil)
+
+	start := time.Now()
+	err := wrapped.Process(ctx)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, errRetryable) {
+		t.Errorf("expected errRetryable to be returned once the deadline budget runs out, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected exactly 1 attempt before giving up, got %d", attempts)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("expected WithRetry to give up almost immediately instead of sleeping toward a doomed deadline, took %s", elapsed)
+	}
+}
+
+func TestWithRetry_RespectsMaxElapsed(t *testing.T) {
+	attempts := 0
+	task := &fakeTask{name: "t", process: func(ctx context.Context) error {
+		attempts++
+		return errRetryable
+	}}
+
+	wrapped := WithRetry(task, RetryPolicy{
+		MaxAttempts:   10,
+		BaseBackoff:   50 * time.Millisecond,
+		MaxElapsed:    60 * time.Millisecond,
+		RetryableFunc: alwaysRetryable,
+	}, nil)
+
+	err := wrapped.Process(context.Background())
+
+	if !errors.Is(err, errRetryable) {
+		t.Errorf("expected errRetryable, got: %v", err)
+	}
+	if attempts >= 10 {
+		t.Errorf("expected MaxElapsed to stop retries well before exhausting MaxAttempts, got %d attempts", attempts)
+	}
+}
@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	logger "github.com/openshift-online/ocm-service-common/pkg/ocmlogger"
+)
+
+// StdinTasks is implemented by jobs whose tasks can be supplied by the caller on stdin as a JSON
+// batch, instead of (or in addition to) GetTasks inferring a single task from the environment.
+// This keeps bulky or sensitive task inputs (e.g. a pull secret) out of the environment table and
+// lets one invocation process a batch of N tasks. CommandBuilder prefers TasksFromStdin over
+// GetTasks whenever stdin has data piped in; GetTasks remains as the environment-variable-driven
+// fallback for k8s Job compatibility.
+type StdinTasks interface {
+	Job
+	// TasksFromStdin decodes a caller-supplied batch of task inputs from decoder and returns the
+	// resulting tasks.
+	TasksFromStdin(decoder *json.Decoder) ([]Task, error)
+}
+
+// TaskResult is the per-task outcome written back to the caller through an Encoder, so a
+// controller invoking the job can correlate each stdin-supplied input to how it turned out.
+type TaskResult struct {
+	TaskName string `json:"taskName"`
+	Error    string `json:"error,omitempty"`
+}
+
+// WithResultEncoding wraps task so that once Process returns, its outcome is written to encoder as
+// a TaskResult. Writes are serialized with mu, since multiple workers may finish concurrently.
+func WithResultEncoding(task Task, encoder *json.Encoder, mu *sync.Mutex) Task {
+	return &resultEncodingTask{Task: task, encoder: encoder, mu: mu}
+}
+
+type resultEncodingTask struct {
+	Task
+	encoder *json.Encoder
+	mu      *sync.Mutex
+}
+
+func (t *resultEncodingTask) Process(ctx context.Context) error {
+	err := t.Task.Process(ctx)
+
+	result := TaskResult{TaskName: t.TaskName()}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	t.mu.Lock()
+	encodeErr := t.encoder.Encode(result)
+	t.mu.Unlock()
+	if encodeErr != nil {
+		logger.NewOCMLogger(ctx).Contextual().Error(encodeErr, fmt.Sprintf("failed to encode result for task %s", t.TaskName()))
+	}
+
+	return err
+}
@@ -0,0 +1,51 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestWithResultEncoding_WritesSuccessAndFailure(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	var mu sync.Mutex
+
+	ok := &policyFakeTask{name: "ok", process: func(ctx context.Context) error { return nil }}
+	failing := &policyFakeTask{name: "bad", process: func(ctx context.Context) error { return errors.New("boom") }}
+
+	wrapped := []Task{
+		WithResultEncoding(ok, encoder, &mu),
+		WithResultEncoding(failing, encoder, &mu),
+	}
+	for _, task := range wrapped {
+		_ = task.Process(context.Background())
+	}
+
+	decoder := json.NewDecoder(&buf)
+
+	var first TaskResult
+	if err := decoder.Decode(&first); err != nil {
+		t.Fatalf("failed to decode first result: %v", err)
+	}
+	if first.TaskName != "ok" || first.Error != "" {
+		t.Errorf("unexpected first result: %+v", first)
+	}
+
+	var second TaskResult
+	if err := decoder.Decode(&second); err != nil {
+		t.Fatalf("failed to decode second result: %v", err)
+	}
+	if second.TaskName != "bad" || second.Error != "boom" {
+		t.Errorf("unexpected second result: %+v", second)
+	}
+}
+
+func TestStdinHasData(t *testing.T) {
+	if !stdinHasData(bytes.NewBufferString("{}")) {
+		t.Error("expected a non-*os.File reader to be treated as having data")
+	}
+}
@@ -0,0 +1,108 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/openshift-online/ocm-service-common/pkg/ocmlogger"
+)
+
+type taskLoggerKey struct{}
+
+// TaskLogger is a small fluent wrapper around ocmlogger's contextual logger. It reproduces the
+// JSON schema previously hand-rolled by ad-hoc fmt.Println-based logging in individual tasks
+// (timestamp and level are added by ocmlogger itself; operation and duration_ms are added here),
+// while automatically carrying whatever trace fields AddTraceContext attached to ctx (jobId,
+// taskId, workerId).
+type TaskLogger struct {
+	ctx       context.Context
+	operation string
+	duration  time.Duration
+	fields    []interface{}
+}
+
+// NewTaskLogger creates a TaskLogger bound to ctx.
+func NewTaskLogger(ctx context.Context) TaskLogger {
+	return TaskLogger{ctx: ctx}
+}
+
+// WithTaskLogger attaches logger to ctx so Task.Process implementations can retrieve it via
+// TaskLoggerFromContext.
+func WithTaskLogger(ctx context.Context, tl TaskLogger) context.Context {
+	tl.ctx = ctx
+	return context.WithValue(ctx, taskLoggerKey{}, tl)
+}
+
+// TaskLoggerFromContext returns the TaskLogger attached to ctx by the worker pool, or a fresh one
+// bound to ctx if none was attached (e.g. when calling a task directly from a test).
+func TaskLoggerFromContext(ctx context.Context) TaskLogger {
+	if tl, ok := ctx.Value(taskLoggerKey{}).(TaskLogger); ok {
+		tl.ctx = ctx
+		return tl
+	}
+	return TaskLogger{ctx: ctx}
+}
+
+// Op returns a copy of the logger scoped to the named operation.
+func (l TaskLogger) Op(name string) TaskLogger {
+	l.operation = name
+	return l
+}
+
+// Duration returns a copy of the logger annotated with how long the operation took.
+func (l TaskLogger) Duration(d time.Duration) TaskLogger {
+	l.duration = d
+	return l
+}
+
+// With returns a copy of the logger with additional key/value fields attached to every log line.
+func (l TaskLogger) With(keyvals ...interface{}) TaskLogger {
+	l.fields = append(append([]interface{}{}, l.fields...), keyvals...)
+	return l
+}
+
+func (l TaskLogger) keyvals() []interface{} {
+	kv := append([]interface{}{}, l.fields...)
+	if l.operation != "" {
+		kv = append(kv, "operation", l.operation)
+	}
+	if l.duration > 0 {
+		kv = append(kv, "duration_ms", l.duration.Milliseconds())
+	}
+	return kv
+}
+
+// logSink is the minimal interface TaskLogger writes through. Production code always uses
+// ocmLoggerSink (so downstream log pipelines keep seeing ocmlogger's JSON schema); tests can
+// temporarily replace newLogSink with a recording fake to assert on structured attributes instead
+// of merely checking that logging doesn't panic.
+type logSink interface {
+	Info(msg string, keyvals ...interface{})
+	Error(err error, msg string, keyvals ...interface{})
+}
+
+type ocmLoggerSink struct {
+	ctx context.Context
+}
+
+func (s ocmLoggerSink) Info(msg string, keyvals ...interface{}) {
+	logger.NewOCMLogger(s.ctx).Contextual().Info(msg, keyvals...)
+}
+
+func (s ocmLoggerSink) Error(err error, msg string, keyvals ...interface{}) {
+	logger.NewOCMLogger(s.ctx).Contextual().Error(err, msg, keyvals...)
+}
+
+// newLogSink returns the logSink TaskLogger should write through, defaulting to ocmLoggerSink
+// unless a test has reassigned it to a recording fake.
+var newLogSink = func(ctx context.Context) logSink {
+	return ocmLoggerSink{ctx: ctx}
+}
+
+func (l TaskLogger) Info(msg string) {
+	newLogSink(l.ctx).Info(msg, l.keyvals()...)
+}
+
+func (l TaskLogger) Error(err error, msg string) {
+	newLogSink(l.ctx).Error(err, msg, l.keyvals()...)
+}
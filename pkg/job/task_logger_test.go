@@ -0,0 +1,92 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskLogger_DoesNotPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("TaskLogger panicked: %v", r)
+		}
+	}()
+
+	tlog := NewTaskLogger(context.Background()).With("cluster_id", "cls-123")
+	tlog.Op("create-secret").Duration(100 * time.Millisecond).Info("created")
+	tlog.Op("create-secret").Error(errors.New("boom"), "failed")
+}
+
+func TestTaskLoggerFromContext_RoundTrips(t *testing.T) {
+	ctx := WithTaskLogger(context.Background(), NewTaskLogger(context.Background()).With("task_id", "abc"))
+
+	tlog := TaskLoggerFromContext(ctx)
+	if len(tlog.fields) != 2 {
+		t.Errorf("expected fields to round-trip through context, got %v", tlog.fields)
+	}
+}
+
+func TestTaskLoggerFromContext_DefaultsWhenUnset(t *testing.T) {
+	tlog := TaskLoggerFromContext(context.Background())
+	if len(tlog.fields) != 0 {
+		t.Errorf("expected no fields for a bare context, got %v", tlog.fields)
+	}
+}
+
+// recordingSink is a logSink test double that captures structured attributes instead of writing
+// anywhere, so tests can assert on them directly rather than only checking for a panic.
+type recordingSink struct {
+	msg     string
+	err     error
+	keyvals []interface{}
+}
+
+func (s *recordingSink) Info(msg string, keyvals ...interface{}) {
+	s.msg = msg
+	s.keyvals = keyvals
+}
+
+func (s *recordingSink) Error(err error, msg string, keyvals ...interface{}) {
+	s.msg = msg
+	s.err = err
+	s.keyvals = keyvals
+}
+
+func withRecordingSink(t *testing.T) *recordingSink {
+	t.Helper()
+	sink := &recordingSink{}
+	previous := newLogSink
+	newLogSink = func(ctx context.Context) logSink { return sink }
+	t.Cleanup(func() { newLogSink = previous })
+	return sink
+}
+
+func TestTaskLogger_AttachesStructuredAttributes(t *testing.T) {
+	sink := withRecordingSink(t)
+
+	NewTaskLogger(context.Background()).
+		With("cluster_id", "cls-123").
+		Op("create-secret").
+		Duration(250 * time.Millisecond).
+		Info("created")
+
+	if sink.msg != "created" {
+		t.Errorf("expected message 'created', got %q", sink.msg)
+	}
+
+	got := map[interface{}]interface{}{}
+	for i := 0; i+1 < len(sink.keyvals); i += 2 {
+		got[sink.keyvals[i]] = sink.keyvals[i+1]
+	}
+	if got["cluster_id"] != "cls-123" {
+		t.Errorf("expected cluster_id attribute, got %v", got)
+	}
+	if got["operation"] != "create-secret" {
+		t.Errorf("expected operation attribute, got %v", got)
+	}
+	if got["duration_ms"] != int64(250) {
+		t.Errorf("expected duration_ms attribute, got %v", got)
+	}
+}
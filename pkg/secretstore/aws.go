@@ -0,0 +1,80 @@
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/smithy-go"
+)
+
+// AWSBackend stores secrets in AWS Secrets Manager.
+type AWSBackend struct {
+	Client *secretsmanager.Client
+}
+
+// NewAWSBackend returns a Backend backed by AWS Secrets Manager.
+func NewAWSBackend(client *secretsmanager.Client) *AWSBackend {
+	return &AWSBackend{Client: client}
+}
+
+func (b *AWSBackend) Exists(ctx context.Context, name string) (bool, error) {
+	_, err := b.Client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{SecretId: aws.String(name)})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check secret existence: %w", err)
+	}
+	return true, nil
+}
+
+func (b *AWSBackend) Create(ctx context.Context, name string, labels map[string]string) error {
+	tags := make([]types.Tag, 0, len(labels))
+	for k, v := range labels {
+		tags = append(tags, types.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	_, err := b.Client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+		Name: aws.String(name),
+		Tags: tags,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+	return nil
+}
+
+func (b *AWSBackend) PutVersion(ctx context.Context, name string, data []byte) (string, error) {
+	out, err := b.Client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(name),
+		SecretBinary: data,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to put secret value: %w", err)
+	}
+	return aws.ToString(out.VersionId), nil
+}
+
+func (b *AWSBackend) Access(ctx context.Context, name string) ([]byte, error) {
+	out, err := b.Client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret value: %w", err)
+	}
+	if out.SecretBinary != nil {
+		return out.SecretBinary, nil
+	}
+	return []byte(aws.ToString(out.SecretString)), nil
+}
+
+func (b *AWSBackend) IsRetryable(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorFault() == smithy.FaultServer
+	}
+	return false
+}
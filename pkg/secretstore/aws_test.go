@@ -0,0 +1,31 @@
+package secretstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestAWSBackend_IsRetryable(t *testing.T) {
+	b := &AWSBackend{}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"ServerFault", &smithy.GenericAPIError{Code: "InternalServiceError", Fault: smithy.FaultServer}, true},
+		{"ClientFault", &smithy.GenericAPIError{Code: "ResourceNotFoundException", Fault: smithy.FaultClient}, false},
+		{"UnknownFault", &smithy.GenericAPIError{Code: "Unknown", Fault: smithy.FaultUnknown}, false},
+		{"not an APIError", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.IsRetryable(tt.err); got != tt.expected {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
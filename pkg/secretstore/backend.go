@@ -0,0 +1,21 @@
+// Package secretstore abstracts the storage of pull secrets behind a single Backend interface, so
+// callers such as PullSecretTask don't need to know whether secrets live in GCP Secret Manager,
+// HashiCorp Vault, or AWS Secrets Manager.
+package secretstore
+
+import "context"
+
+// Backend is a pluggable secret storage backend.
+type Backend interface {
+	// Exists reports whether a secret with the given name already exists.
+	Exists(ctx context.Context, name string) (bool, error)
+	// Create creates a new, empty secret with the given name, annotated with labels.
+	Create(ctx context.Context, name string, labels map[string]string) error
+	// PutVersion writes a new version of the secret's data, returning an opaque version identifier.
+	PutVersion(ctx context.Context, name string, data []byte) (versionID string, err error)
+	// Access returns the latest version's data.
+	Access(ctx context.Context, name string) ([]byte, error)
+	// IsRetryable reports whether err, as returned by any method on this Backend, is a transient
+	// failure worth retrying. Each backend classifies errors using its own SDK's conventions.
+	IsRetryable(err error) bool
+}
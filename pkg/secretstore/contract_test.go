@@ -0,0 +1,143 @@
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// memoryBackend is an in-memory Backend used to exercise the contract every real backend (GCP,
+// Vault, AWS) must also satisfy. It has no external dependencies, so it runs in every test
+// environment; the GCP/Vault/AWS backends need a live (or emulated) service to run this suite
+// against, so those backends are instead covered by narrower unit tests (e.g. IsRetryable table
+// tests in gcp_test.go, vault_test.go, aws_test.go) that don't require one.
+type memoryBackend struct {
+	mu      sync.Mutex
+	secrets map[string][][]byte
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{secrets: make(map[string][][]byte)}
+}
+
+var errMemoryBackendNotFound = errors.New("secret not found")
+
+func (b *memoryBackend) Exists(ctx context.Context, name string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.secrets[name]
+	return ok, nil
+}
+
+func (b *memoryBackend) Create(ctx context.Context, name string, labels map[string]string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.secrets[name]; !ok {
+		b.secrets[name] = nil
+	}
+	return nil
+}
+
+func (b *memoryBackend) PutVersion(ctx context.Context, name string, data []byte) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.secrets[name] = append(b.secrets[name], append([]byte(nil), data...))
+	return strconv.Itoa(len(b.secrets[name])), nil
+}
+
+func (b *memoryBackend) Access(ctx context.Context, name string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	versions, ok := b.secrets[name]
+	if !ok || len(versions) == 0 {
+		return nil, errMemoryBackendNotFound
+	}
+	return versions[len(versions)-1], nil
+}
+
+func (b *memoryBackend) IsRetryable(err error) bool {
+	return false
+}
+
+// runBackendContractTests exercises the full Backend contract against newBackend(). Every backend
+// implementation should be run through this suite.
+func runBackendContractTests(t *testing.T, newBackend func() Backend) {
+	t.Helper()
+
+	t.Run("secret does not exist before creation", func(t *testing.T) {
+		backend := newBackend()
+		exists, err := backend.Exists(context.Background(), "missing-secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists {
+			t.Error("expected secret to not exist")
+		}
+	})
+
+	t.Run("create then exists", func(t *testing.T) {
+		backend := newBackend()
+		ctx := context.Background()
+		if err := backend.Create(ctx, "my-secret", map[string]string{"owner": "pull-secret"}); err != nil {
+			t.Fatalf("unexpected error creating secret: %v", err)
+		}
+		exists, err := backend.Exists(ctx, "my-secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !exists {
+			t.Error("expected secret to exist after Create")
+		}
+	})
+
+	t.Run("put then access round-trips data", func(t *testing.T) {
+		backend := newBackend()
+		ctx := context.Background()
+		payload := []byte(`{"auths":{}}`)
+
+		if err := backend.Create(ctx, "rt-secret", nil); err != nil {
+			t.Fatalf("unexpected error creating secret: %v", err)
+		}
+		if _, err := backend.PutVersion(ctx, "rt-secret", payload); err != nil {
+			t.Fatalf("unexpected error putting version: %v", err)
+		}
+
+		got, err := backend.Access(ctx, "rt-secret")
+		if err != nil {
+			t.Fatalf("unexpected error accessing secret: %v", err)
+		}
+		if !bytes.Equal(got, payload) {
+			t.Errorf("expected %q, got %q", payload, got)
+		}
+	})
+
+	t.Run("access returns latest version", func(t *testing.T) {
+		backend := newBackend()
+		ctx := context.Background()
+
+		if err := backend.Create(ctx, "versioned-secret", nil); err != nil {
+			t.Fatalf("unexpected error creating secret: %v", err)
+		}
+		if _, err := backend.PutVersion(ctx, "versioned-secret", []byte("v1")); err != nil {
+			t.Fatalf("unexpected error putting v1: %v", err)
+		}
+		if _, err := backend.PutVersion(ctx, "versioned-secret", []byte("v2")); err != nil {
+			t.Fatalf("unexpected error putting v2: %v", err)
+		}
+
+		got, err := backend.Access(ctx, "versioned-secret")
+		if err != nil {
+			t.Fatalf("unexpected error accessing secret: %v", err)
+		}
+		if string(got) != "v2" {
+			t.Errorf("expected latest version 'v2', got %q", got)
+		}
+	})
+}
+
+func TestMemoryBackend_SatisfiesContract(t *testing.T) {
+	runBackendContractTests(t, func() Backend { return newMemoryBackend() })
+}
@@ -0,0 +1,85 @@
+package secretstore
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GCPBackend stores secrets in GCP Secret Manager.
+type GCPBackend struct {
+	Client    *secretmanager.Client
+	ProjectID string
+}
+
+// NewGCPBackend returns a Backend backed by GCP Secret Manager in the given project.
+func NewGCPBackend(client *secretmanager.Client, projectID string) *GCPBackend {
+	return &GCPBackend{Client: client, ProjectID: projectID}
+}
+
+func (b *GCPBackend) Exists(ctx context.Context, name string) (bool, error) {
+	_, err := b.Client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: b.secretPath(name)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check secret existence: %w", err)
+	}
+	return true, nil
+}
+
+func (b *GCPBackend) Create(ctx context.Context, name string, labels map[string]string) error {
+	_, err := b.Client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   fmt.Sprintf("projects/%s", b.ProjectID),
+		SecretId: name,
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+			Labels: labels,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+	return nil
+}
+
+func (b *GCPBackend) PutVersion(ctx context.Context, name string, data []byte) (string, error) {
+	version, err := b.Client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  b.secretPath(name),
+		Payload: &secretmanagerpb.SecretPayload{Data: data},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to add secret version: %w", err)
+	}
+	return version.Name, nil
+}
+
+func (b *GCPBackend) Access(ctx context.Context, name string) ([]byte, error) {
+	result, err := b.Client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: b.secretPath(name) + "/versions/latest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to access secret version: %w", err)
+	}
+	return result.Payload.Data, nil
+}
+
+func (b *GCPBackend) IsRetryable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable ||
+		code == codes.DeadlineExceeded ||
+		code == codes.Internal ||
+		code == codes.ResourceExhausted
+}
+
+func (b *GCPBackend) secretPath(name string) string {
+	return fmt.Sprintf("projects/%s/secrets/%s", b.ProjectID, name)
+}
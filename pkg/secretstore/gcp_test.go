@@ -0,0 +1,35 @@
+package secretstore
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGCPBackend_IsRetryable(t *testing.T) {
+	b := &GCPBackend{}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Unavailable", status.Error(codes.Unavailable, "service unavailable"), true},
+		{"DeadlineExceeded", status.Error(codes.DeadlineExceeded, "deadline exceeded"), true},
+		{"Internal", status.Error(codes.Internal, "internal error"), true},
+		{"ResourceExhausted", status.Error(codes.ResourceExhausted, "rate limit exceeded"), true},
+		{"PermissionDenied", status.Error(codes.PermissionDenied, "permission denied"), false},
+		{"NotFound", status.Error(codes.NotFound, "not found"), false},
+		{"AlreadyExists", status.Error(codes.AlreadyExists, "already exists"), false},
+		{"InvalidArgument", status.Error(codes.InvalidArgument, "invalid argument"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.IsRetryable(tt.err); got != tt.expected {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package secretstore
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// dockerConfigJSONKey is the well-known data key Kubernetes expects for a Secret of type
+// kubernetes.io/dockerconfigjson.
+const dockerConfigJSONKey = ".dockerconfigjson"
+
+// KubernetesBackend stores secrets as Kubernetes Secrets of type kubernetes.io/dockerconfigjson in
+// a single namespace.
+type KubernetesBackend struct {
+	Client    kubernetes.Interface
+	Namespace string
+}
+
+// NewKubernetesBackend returns a Backend backed by Kubernetes Secrets in the given namespace.
+func NewKubernetesBackend(client kubernetes.Interface, namespace string) *KubernetesBackend {
+	return &KubernetesBackend{Client: client, Namespace: namespace}
+}
+
+func (b *KubernetesBackend) Exists(ctx context.Context, name string) (bool, error) {
+	_, err := b.Client.CoreV1().Secrets(b.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check secret existence: %w", err)
+	}
+	return true, nil
+}
+
+func (b *KubernetesBackend) Create(ctx context.Context, name string, labels map[string]string) error {
+	_, err := b.Client.CoreV1().Secrets(b.Namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: b.Namespace,
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+	return nil
+}
+
+func (b *KubernetesBackend) PutVersion(ctx context.Context, name string, data []byte) (string, error) {
+	secret, err := b.Client.CoreV1().Secrets(b.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret before update: %w", err)
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[dockerConfigJSONKey] = data
+
+	updated, err := b.Client.CoreV1().Secrets(b.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to update secret: %w", err)
+	}
+	return updated.ResourceVersion, nil
+}
+
+func (b *KubernetesBackend) Access(ctx context.Context, name string) ([]byte, error) {
+	secret, err := b.Client.CoreV1().Secrets(b.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+	data, ok := secret.Data[dockerConfigJSONKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %q missing %q key", name, dockerConfigJSONKey)
+	}
+	return data, nil
+}
+
+func (b *KubernetesBackend) IsRetryable(err error) bool {
+	return apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsTooManyRequests(err)
+}
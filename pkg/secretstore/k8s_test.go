@@ -0,0 +1,44 @@
+package secretstore
+
+import (
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// TestKubernetesBackend_SatisfiesContract runs the shared Backend contract suite against the fake
+// clientset, since (unlike GCP/Vault/AWS) it requires no live service to exercise.
+func TestKubernetesBackend_SatisfiesContract(t *testing.T) {
+	runBackendContractTests(t, func() Backend {
+		return NewKubernetesBackend(fake.NewSimpleClientset(), "hyperfleet")
+	})
+}
+
+func TestKubernetesBackend_IsRetryable(t *testing.T) {
+	secretResource := schema.GroupResource{Resource: "secrets"}
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{name: "server timeout", err: apierrors.NewServerTimeout(secretResource, "get", 1), retryable: true},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("down"), retryable: true},
+		{name: "too many requests", err: apierrors.NewTooManyRequests("throttled", 1), retryable: true},
+		{name: "internal error", err: apierrors.NewInternalError(errors.New("boom")), retryable: true},
+		{name: "not found", err: apierrors.NewNotFound(secretResource, "my-secret"), retryable: false},
+		{name: "conflict", err: apierrors.NewConflict(secretResource, "my-secret", errors.New("conflict")), retryable: false},
+	}
+
+	backend := &KubernetesBackend{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backend.IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.retryable)
+			}
+		})
+	}
+}
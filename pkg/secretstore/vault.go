@@ -0,0 +1,74 @@
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// VaultBackend stores secrets in a HashiCorp Vault KV version 2 secrets engine. The secret's
+// payload is stored under a single "data" field so Access/PutVersion round-trip arbitrary bytes.
+type VaultBackend struct {
+	Client *vaultapi.Client
+	Mount  string
+}
+
+// NewVaultBackend returns a Backend backed by the KV v2 engine mounted at mount.
+func NewVaultBackend(client *vaultapi.Client, mount string) *VaultBackend {
+	return &VaultBackend{Client: client, Mount: mount}
+}
+
+func (b *VaultBackend) Exists(ctx context.Context, name string) (bool, error) {
+	secret, err := b.Client.KVv2(b.Mount).Get(ctx, name)
+	if err != nil {
+		if isVaultNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check secret existence: %w", err)
+	}
+	return secret != nil, nil
+}
+
+func (b *VaultBackend) Create(ctx context.Context, name string, labels map[string]string) error {
+	// KV v2 has no separate create step; the first PutVersion call creates the secret implicitly.
+	return nil
+}
+
+func (b *VaultBackend) PutVersion(ctx context.Context, name string, data []byte) (string, error) {
+	secret, err := b.Client.KVv2(b.Mount).Put(ctx, name, map[string]interface{}{"data": string(data)})
+	if err != nil {
+		return "", fmt.Errorf("failed to write secret version: %w", err)
+	}
+	return fmt.Sprintf("%d", secret.VersionMetadata.Version), nil
+}
+
+func (b *VaultBackend) Access(ctx context.Context, name string) ([]byte, error) {
+	secret, err := b.Client.KVv2(b.Mount).Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret: %w", err)
+	}
+	data, ok := secret.Data["data"].(string)
+	if !ok {
+		return nil, fmt.Errorf("secret %q missing 'data' field", name)
+	}
+	return []byte(data), nil
+}
+
+func (b *VaultBackend) IsRetryable(err error) bool {
+	var respErr *vaultapi.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+func isVaultNotFound(err error) bool {
+	var respErr *vaultapi.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
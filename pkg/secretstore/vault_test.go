@@ -0,0 +1,54 @@
+package secretstore
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+func TestVaultBackend_IsRetryable(t *testing.T) {
+	b := &VaultBackend{}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"TooManyRequests", &vaultapi.ResponseError{StatusCode: http.StatusTooManyRequests}, true},
+		{"InternalServerError", &vaultapi.ResponseError{StatusCode: http.StatusInternalServerError}, true},
+		{"BadGateway", &vaultapi.ResponseError{StatusCode: http.StatusBadGateway}, true},
+		{"NotFound", &vaultapi.ResponseError{StatusCode: http.StatusNotFound}, false},
+		{"Forbidden", &vaultapi.ResponseError{StatusCode: http.StatusForbidden}, false},
+		{"not a ResponseError", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.IsRetryable(tt.err); got != tt.expected {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsVaultNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"NotFound", &vaultapi.ResponseError{StatusCode: http.StatusNotFound}, true},
+		{"Forbidden", &vaultapi.ResponseError{StatusCode: http.StatusForbidden}, false},
+		{"not a ResponseError", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isVaultNotFound(tt.err); got != tt.expected {
+				t.Errorf("isVaultNotFound(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}